@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AllOf finds variables of the type T, with the package and the name of the
+// type taken from T so there are no strings to keep in sync when the type is
+// renamed or moved.
+//
+// T has to be a named, non-generic type in a package that can be imported,
+// the type is looked up by its import path from the current directory.
+//
+// Example:
+//
+//	AllOf[feature.Flag]()
+func AllOf[T any](opts ...Option) (Collection, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Name() == "" || typ.PkgPath() == "" {
+		return Collection{}, fmt.Errorf("%s is not a named type declared in a package", typ)
+	}
+	if strings.Contains(typ.Name(), "[") {
+		return Collection{}, fmt.Errorf("%s is a generic type, which isn't supported", typ)
+	}
+	if typ.PkgPath() == "main" {
+		return Collection{}, fmt.Errorf("%s is declared in package main, which can't be imported", typ)
+	}
+
+	return All(typ.PkgPath(), typ.PkgPath()+"."+typ.Name(), opts...)
+}
@@ -0,0 +1,36 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+	"github.com/gaqzi/enums/testdata/full"
+)
+
+type generic[T any] struct{}
+
+func TestAllOf(t *testing.T) {
+	t.Run("finds the enums of the type", func(t *testing.T) {
+		expected, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+
+		matches, err := enums.AllOf[full.Flag]()
+		require.NoError(t, err)
+
+		require.Equal(t, expected, matches)
+	})
+
+	t.Run("fails for types without a name", func(t *testing.T) {
+		_, err := enums.AllOf[*full.Flag]()
+
+		require.EqualError(t, err, "*full.Flag is not a named type declared in a package")
+	})
+
+	t.Run("fails for generic types", func(t *testing.T) {
+		_, err := enums.AllOf[generic[int]]()
+
+		require.EqualError(t, err, "enums_test.generic[int] is a generic type, which isn't supported")
+	})
+}
@@ -0,0 +1,59 @@
+package enums
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// avroName is what Avro allows as the name of a type or symbol.
+var avroName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// avroEnum is an Avro enum schema, in the order the fields are written.
+type avroEnum struct {
+	Type    string   `json:"type"`
+	Name    string   `json:"name"`
+	Doc     string   `json:"doc,omitempty"`
+	Symbols []string `json:"symbols"`
+}
+
+// WriteAvroSchema writes an Avro enum schema with the values of the
+// collection as its symbols, for Kafka contracts that must have exactly the
+// values the producer can emit. The schema is named after the type.
+//
+// Avro only allows letters, digits, and underscores in symbols, so values
+// with any other characters are an error rather than silently renamed.
+//
+// Example:
+//
+//	flags, _ := All("./feature", "feature.Flag")
+//	WriteAvroSchema(os.Stdout, flags)
+func WriteAvroSchema(w io.Writer, c Collection) error {
+	if c.Type == "" {
+		return errors.New("collection has no type, nothing to name the schema")
+	}
+	if c.FieldName != "" || c.Interface {
+		return fmt.Errorf("%s isn't a string type and can't be an Avro enum", c.Type)
+	}
+
+	schema := avroEnum{
+		Type:    "enum",
+		Name:    typeName(c.Type),
+		Doc:     fmt.Sprintf("The values of %s.", c.Type),
+		Symbols: make([]string, len(c.Enums)),
+	}
+	for i, e := range c.Enums {
+		symbol := e.Unquoted()
+		if !avroName.MatchString(symbol) {
+			return fmt.Errorf("value of %s isn't a valid Avro symbol, only letters, digits, and _ are allowed: %s", e.Name, e.Value)
+		}
+
+		schema.Symbols[i] = symbol
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(schema)
+}
@@ -0,0 +1,46 @@
+package enums_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestWriteAvroSchema(t *testing.T) {
+	t.Run("has the values as symbols", func(t *testing.T) {
+		collection := enums.Collection{
+			Type:  "example.com/orders.Status",
+			Enums: []enums.Enum{{Name: "StatusOpen", Value: `"OPEN"`}, {Name: "StatusClosed", Value: `"CLOSED"`}},
+		}
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteAvroSchema(&buf, collection))
+
+		require.JSONEq(
+			t,
+			`{"type": "enum", "name": "Status", "doc": "The values of example.com/orders.Status.", "symbols": ["OPEN", "CLOSED"]}`,
+			buf.String(),
+		)
+	})
+
+	t.Run("fails for values that aren't valid symbols", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+
+		err = enums.WriteAvroSchema(&bytes.Buffer{}, collection)
+
+		require.EqualError(t, err, `value of DeployAllTheThings isn't a valid Avro symbol, only letters, digits, and _ are allowed: "deploy-all-the-things"`)
+	})
+
+	t.Run("fails for struct enums", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.FlagStruct")
+		require.NoError(t, err)
+
+		err = enums.WriteAvroSchema(&bytes.Buffer{}, collection)
+
+		require.EqualError(t, err, "github.com/gaqzi/enums/testdata/full.FlagStruct isn't a string type and can't be an Avro enum")
+	})
+}
@@ -0,0 +1,83 @@
+package enums_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/gaqzi/enums"
+)
+
+// benchmarkSizes are the number of declarations in the synthetic packages
+// scanned by the benchmarks.
+var benchmarkSizes = []int{100, 1_000, 10_000}
+
+// syntheticModule writes a module with a package declaring decls enums of
+// the type synthetic.Flag, a hundred per file, and returns its directory.
+func syntheticModule(b *testing.B, decls int) string {
+	b.Helper()
+
+	dir := b.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/synthetic\n\ngo 1.21\n",
+		"flag.go": "package synthetic\n\ntype Flag string\n",
+	}
+	for i := 0; i < decls; i += 100 {
+		var src strings.Builder
+		src.WriteString("package synthetic\n\nconst (\n")
+		for j := i; j < min(i+100, decls); j++ {
+			fmt.Fprintf(&src, "\t// Flag%d is a synthetic flag.\n\tFlag%d Flag = \"flag-%d\"\n", j, j, j)
+		}
+		src.WriteString(")\n")
+		files[fmt.Sprintf("flags%d.go", i/100)] = src.String()
+	}
+
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func BenchmarkAll(b *testing.B) {
+	for _, decls := range benchmarkSizes {
+		b.Run(fmt.Sprintf("decls=%d", decls), func(b *testing.B) {
+			dir := syntheticModule(b, decls)
+
+			for b.Loop() {
+				collection, err := enums.All("./...", "synthetic.Flag", enums.WithDir(dir))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(collection.Enums) != decls {
+					b.Fatalf("expected %d enums, got %d", decls, len(collection.Enums))
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkFromPackages measures scanning without loading the packages,
+// which dominates BenchmarkAll.
+func BenchmarkFromPackages(b *testing.B) {
+	for _, decls := range benchmarkSizes {
+		b.Run(fmt.Sprintf("decls=%d", decls), func(b *testing.B) {
+			pkgs, err := packages.Load(&packages.Config{Mode: enums.LoadMode, Dir: syntheticModule(b, decls)}, "./...")
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			for b.Loop() {
+				if _, err := enums.FromPackages(pkgs, "synthetic.Flag"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package enums_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestCollection_MarshalBinary(t *testing.T) {
+	t.Run("round-trips the collection", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.FlagStruct")
+		require.NoError(t, err)
+
+		data, err := collection.MarshalBinary()
+		require.NoError(t, err)
+
+		var decoded enums.Collection
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		require.Equal(t, collection, decoded)
+	})
+
+	t.Run("leaves out the objects", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.Flag", enums.WithObjects())
+		require.NoError(t, err)
+
+		data, err := collection.MarshalBinary()
+		require.NoError(t, err)
+
+		var decoded enums.Collection
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		require.Nil(t, decoded.Enums[0].Object)
+		require.Equal(t, collection.Values(), decoded.Values())
+	})
+
+	t.Run("is used by gob", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(map[string]enums.Collection{"full.Flag": collection}))
+
+		var decoded map[string]enums.Collection
+		require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+		require.Equal(t, collection, decoded["full.Flag"])
+	})
+
+	t.Run("rejects other versions of the encoding", func(t *testing.T) {
+		var decoded enums.Collection
+
+		require.EqualError(t, decoded.UnmarshalBinary([]byte{0}), "unsupported encoding of collection, expected version 1")
+	})
+}
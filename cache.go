@@ -0,0 +1,170 @@
+package enums
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Cache stores the results of scans between runs, keyed by a hash of the
+// source of the scanned packages and their dependencies, so CI jobs sharing
+// a cache don't type check the same packages over and over. Implement it
+// on top of Redis or S3 to share it between machines, FileCache stores it
+// in a directory.
+type Cache interface {
+	// Get returns the data stored for key, ok is false when there is none.
+	Get(ctx context.Context, key string) (data []byte, ok bool, err error)
+	// Put stores data for key, replacing what was stored before.
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// FileCache is a Cache storing every result as a file in Dir, which is
+// created when the first result is stored.
+//
+// Example:
+//
+//	All("./...", "feature.Flag", WithCache(FileCache{Dir: ".cache/enums"}))
+type FileCache struct {
+	Dir string
+}
+
+// Get returns the contents of the file for key.
+func (c FileCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(filepath.Join(c.Dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, true, nil
+}
+
+// Put writes data to the file for key, through a temporary file so
+// concurrent readers never see a partial result.
+func (c FileCache) Put(_ context.Context, key string, data []byte) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), filepath.Join(c.Dir, key))
+}
+
+// cachedScan is scan with its result stored in and read from the cache of
+// c. Failing to use the cache is logged and falls back to scanning.
+func cachedScan(c config, pkg, typ string) (Collection, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	key, err := cacheKey(ctx, c, pkg, typ)
+	if err != nil {
+		c.logger.Debug("failed to hash packages for the cache", "pattern", pkg, "error", err)
+		return scan(c, pkg, typ)
+	}
+
+	data, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.logger.Debug("failed to read from the cache", "key", key, "error", err)
+	}
+	if ok {
+		var collection Collection
+		if err := collection.UnmarshalBinary(data); err == nil {
+			c.logger.Debug("read scan from the cache", "pattern", pkg, "type", typ, "key", key)
+			return collection, nil
+		}
+		c.logger.Debug("failed to decode from the cache", "key", key, "error", err)
+	}
+
+	collection, err := scan(c, pkg, typ)
+	if err != nil {
+		return collection, err
+	}
+
+	if data, err := collection.MarshalBinary(); err != nil {
+		c.logger.Debug("failed to encode for the cache", "key", key, "error", err)
+	} else if err := c.cache.Put(ctx, key, data); err != nil {
+		c.logger.Debug("failed to write to the cache", "key", key, "error", err)
+	}
+
+	return collection, nil
+}
+
+// cacheKey hashes the options of the scan with the source of the packages
+// matching pkg and of their dependencies, listed without type checking
+// them. Dependencies from other modules are hashed by their version and the
+// standard library by the version of Go. The files are hashed with their
+// paths, as the positions of the enums are, so checkouts in different
+// directories don't share results.
+func cacheKey(ctx context.Context, c config, pkg, typ string) (string, error) {
+	cfg := c.packagesConfig(ctx, pkg, packages.NeedName|packages.NeedFiles|packages.NeedImports|packages.NeedDeps|packages.NeedModule)
+	pkgs, err := packages.Load(&cfg, pkg)
+	if err != nil {
+		return "", err
+	}
+	if err := loadErrors(pkgs); err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00%s\x00%s\x00", binaryVersion, runtime.Version(), c.key(pkg, typ))
+
+	var all []*packages.Package
+	packages.Visit(pkgs, nil, func(p *packages.Package) { all = append(all, p) })
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	for _, p := range all {
+		fmt.Fprintf(h, "%s\x00", p.ID)
+		switch {
+		case p.Module == nil:
+			continue // the standard library only changes with the version of Go
+		case p.Module != nil && p.Module.Version != "" && p.Module.Replace == nil:
+			fmt.Fprintf(h, "%s@%s\x00", p.Module.Path, p.Module.Version)
+			continue
+		}
+
+		for _, file := range append(append([]string(nil), p.GoFiles...), p.IgnoredFiles...) {
+			if err := hashFile(h, file); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile writes the name and contents of file to w.
+func hashFile(w io.Writer, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fmt.Fprintf(w, "%s\x00", file)
+	_, err = io.Copy(w, f)
+	return err
+}
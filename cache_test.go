@@ -0,0 +1,88 @@
+package enums_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+// countingCache counts the hits and writes of the cache it wraps.
+type countingCache struct {
+	enums.Cache
+	hits, puts int
+}
+
+func (c *countingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, ok, err := c.Cache.Get(ctx, key)
+	if ok {
+		c.hits++
+	}
+	return data, ok, err
+}
+
+func (c *countingCache) Put(ctx context.Context, key string, data []byte) error {
+	c.puts++
+	return c.Cache.Put(ctx, key, data)
+}
+
+func TestAll_WithCache(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/cached\n\ngo 1.21\n"), 0o644))
+	writeSource := func(src string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "flag.go"), []byte("package cached\n\ntype Flag string\n\n"+src), 0o644))
+	}
+	cache := &countingCache{Cache: enums.FileCache{Dir: filepath.Join(t.TempDir(), "cache")}}
+
+	t.Run("stores the scan in the cache", func(t *testing.T) {
+		writeSource(`const FlagOn Flag = "on"` + "\n")
+
+		matches, err := enums.All("./...", "cached.Flag", enums.WithDir(dir), enums.WithCache(cache))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"on"}, matches.Values())
+		require.Equal(t, 0, cache.hits)
+		require.Equal(t, 1, cache.puts)
+	})
+
+	t.Run("reads the scan from the cache when nothing changed", func(t *testing.T) {
+		uncached, err := enums.All("./...", "cached.Flag", enums.WithDir(dir))
+		require.NoError(t, err)
+
+		matches, err := enums.All("./...", "cached.Flag", enums.WithDir(dir), enums.WithCache(cache))
+		require.NoError(t, err)
+
+		require.Equal(t, uncached, matches)
+		require.Equal(t, 1, cache.hits)
+		require.Equal(t, 1, cache.puts)
+	})
+
+	t.Run("scans again when the source changed", func(t *testing.T) {
+		writeSource(`const FlagOn, FlagOff Flag = "on", "off"` + "\n")
+
+		matches, err := enums.All("./...", "cached.Flag", enums.WithDir(dir), enums.WithCache(cache))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"off", "on"}, matches.Values())
+		require.Equal(t, 1, cache.hits)
+		require.Equal(t, 2, cache.puts)
+	})
+}
+
+func TestFileCache(t *testing.T) {
+	cache := enums.FileCache{Dir: filepath.Join(t.TempDir(), "cache")}
+
+	_, ok, err := cache.Get(context.Background(), "key")
+	require.NoError(t, err)
+	require.False(t, ok, "a missing key isn't an error")
+
+	require.NoError(t, cache.Put(context.Background(), "key", []byte("data")))
+	data, ok, err := cache.Get(context.Background(), "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("data"), data)
+}
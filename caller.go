@@ -0,0 +1,32 @@
+package enums
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// CallerRelative returns pkg resolved relative to the directory of the file
+// calling CallerRelative instead of the current directory, so tests find the
+// same packages no matter which directory go test is run from. Patterns that
+// aren't relative, like import paths, are returned as is.
+//
+// The path is absolute, which All loads from its own directory so it
+// resolves in the module it's in. When the binary is built with -trimpath
+// the file isn't known and pkg is returned as is.
+//
+// Example:
+//
+//	All(CallerRelative("./feature"), "feature.Flag")
+func CallerRelative(pkg string) string {
+	if pkg != "." && pkg != ".." && !strings.HasPrefix(pkg, "./") && !strings.HasPrefix(pkg, "../") {
+		return pkg
+	}
+
+	_, file, _, ok := runtime.Caller(1)
+	if !ok || !filepath.IsAbs(file) {
+		return pkg
+	}
+
+	return filepath.Join(filepath.Dir(file), pkg)
+}
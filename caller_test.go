@@ -0,0 +1,34 @@
+package enums_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestCallerRelative(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	t.Run("resolves relative to the calling file", func(t *testing.T) {
+		require.Equal(t, filepath.Join(wd, "testdata", "full"), enums.CallerRelative("./testdata/full"))
+		require.Equal(t, filepath.Join(wd, "testdata", "..."), enums.CallerRelative("./testdata/..."))
+	})
+
+	t.Run("returns import paths as is", func(t *testing.T) {
+		require.Equal(t, "github.com/gaqzi/enums/testdata/full", enums.CallerRelative("github.com/gaqzi/enums/testdata/full"))
+	})
+
+	t.Run("All finds the package from another directory", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		matches, err := enums.All(enums.CallerRelative("./testdata/full"), "full.Flag")
+		require.NoError(t, err)
+
+		require.Len(t, matches.Enums, 2)
+	})
+}
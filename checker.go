@@ -0,0 +1,145 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Check is a diff of the enums of a type against the values handling them.
+type Check struct {
+	Package string      // the pattern of the packages the type is declared in, e.g. ./feature
+	Type    string      // the type, e.g. feature.Flag
+	Actual  interface{} // the values handling the enums, anything Collection.Diff accepts
+	Options DiffOptions
+	Exclude []string // the names of the enums that don't have to be handled, such as internal sentinels
+}
+
+// String outputs the check as the type and the packages it's declared in.
+func (c Check) String() string {
+	return fmt.Sprintf("%s in %s", c.Type, c.Package)
+}
+
+// CheckResult is the outcome of a Check, Err is set when the type couldn't
+// be scanned and then Diff is zero.
+type CheckResult struct {
+	Check Check
+	Diff  Diff
+	Err   error
+}
+
+// CheckResults are the results of every check, in the order of the checks.
+type CheckResults []CheckResult
+
+// Zero returns whether every check passed.
+func (r CheckResults) Zero() bool {
+	for _, res := range r {
+		if res.Err != nil || !res.Diff.Zero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String outputs the checks that failed with their diff or error, or an
+// empty string when every check passed.
+func (r CheckResults) String() string {
+	var b strings.Builder
+	for _, res := range r {
+		switch {
+		case res.Err != nil:
+			fmt.Fprintf(&b, "%s: %s\n", res.Check, res.Err)
+		case !res.Diff.Zero():
+			fmt.Fprintf(&b, "%s:\n%s", res.Check, res.Diff)
+		}
+	}
+
+	return b.String()
+}
+
+// Checker runs many checks at once, loading every pattern only once, to
+// cover the enums of a whole service in a single test.
+//
+// Example:
+//
+//	func TestEnums(t *testing.T) {
+//		results := enums.Checker{Checks: []enums.Check{
+//			{Package: "./feature", Type: "feature.Flag", Actual: feature.AllFlags()},
+//			{Package: "./billing", Type: "billing.Plan", Actual: billing.Plans()},
+//		}}.Run()
+//		require.True(t, results.Zero(), results.String())
+//	}
+type Checker struct {
+	Checks  []Check
+	Options []Option // how the packages of every check are loaded and scanned
+}
+
+// Run runs every check, a check failing to load doesn't stop the others.
+// WithTimeout limits loading each pattern and scanning for each check on
+// their own, so a slow check fails with the time it ran out of rather than
+// leaving none for the checks after it.
+func (c Checker) Run() CheckResults {
+	cfg := newConfig(c.Options)
+	byPattern := make(map[string]loaded)
+
+	results := make(CheckResults, len(c.Checks))
+	for i, check := range c.Checks {
+		l, ok := byPattern[check.Package]
+		if !ok {
+			l = loadPattern(cfg, check.Package)
+			byPattern[check.Package] = l
+		}
+
+		results[i] = runCheck(cfg, check, l)
+	}
+
+	return results
+}
+
+// loaded is the outcome of loading the packages of a pattern.
+type loaded struct {
+	pkgs []*packages.Package
+	err  error
+}
+
+// loadPattern loads the packages of pattern with a timeout of its own.
+func loadPattern(c config, pattern string) loaded {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, err := load(ctx, c, pattern)
+	return loaded{pkgs: pkgs, err: err}
+}
+
+// runCheck scans the loaded packages for the type of check, with a timeout
+// of its own, and diffs the enums against the values handling them.
+func runCheck(c config, check Check, l loaded) CheckResult {
+	result := CheckResult{Check: check}
+	if l.err != nil {
+		result.Err = l.err
+		return result
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	collection, err := collect(ctx, c, l.pkgs, check.Type)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if len(check.Exclude) > 0 {
+		collection = collection.Filter(func(e Enum) bool { return !slices.Contains(check.Exclude, e.Name) })
+	}
+	if len(collection.Enums) == 0 {
+		result.Err = errors.New("no enums found")
+		return result
+	}
+
+	result.Diff = collection.DiffWith(check.Actual, check.Options)
+	return result
+}
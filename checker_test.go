@@ -0,0 +1,60 @@
+package enums_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+	"github.com/gaqzi/enums/testdata/full"
+)
+
+func TestChecker(t *testing.T) {
+	t.Run("passes when every check passes", func(t *testing.T) {
+		results := enums.Checker{Checks: []enums.Check{
+			{Package: "./testdata/full", Type: "full.Flag", Actual: full.AllFlags()},
+			{Package: "./testdata/full", Type: "full.FlagStruct", Actual: full.AllFlagStruct()},
+		}}.Run()
+
+		require.True(t, results.Zero(), results.String())
+		require.Empty(t, results.String())
+	})
+
+	t.Run("returns the result of every check", func(t *testing.T) {
+		results := enums.Checker{Checks: []enums.Check{
+			{Package: "./testdata/full", Type: "full.Flag", Actual: full.MissingFlags()},
+			{Package: "./testdata/full", Type: "full.Flag", Actual: full.MissingFlags(), Exclude: []string{"DeployOneThing"}},
+			{Package: "./testdata/full", Type: "full.Falg", Actual: full.AllFlags()},
+		}}.Run()
+
+		require.False(t, results.Zero())
+		require.Len(t, results, 3)
+		require.Equal(t, []string{"deploy-one-thing"}, results[0].Diff.Missing.Values())
+		require.True(t, results[1].Diff.Zero(), "expected the excluded enum to not be missing")
+
+		var notFound *enums.TypeNotFoundError
+		require.ErrorAs(t, results[2].Err, &notFound, "a check failing doesn't stop the others")
+		require.Equal(
+			t,
+			"full.Flag in ./testdata/full:\n"+
+				"Enums declared but not part of actual:\n"+
+				"\tDeployOneThing = \"deploy-one-thing\" (declared at testdata/full/example.go:7)\n"+
+				"full.Falg in ./testdata/full: type full.Falg not found in packages: github.com/gaqzi/enums/testdata/full\n",
+			results.String(),
+		)
+	})
+	t.Run("reports which check ran out of time", func(t *testing.T) {
+		results := enums.Checker{Checks: []enums.Check{
+			{Package: "./testdata/full", Type: "full.Flag", Actual: full.AllFlags()},
+			{Package: "./testdata/multimatch", Type: "multimatch.Flag", Actual: []string{}},
+		}, Options: []enums.Option{enums.WithTimeout(time.Nanosecond)}}.Run()
+
+		require.Equal(
+			t,
+			"full.Flag in ./testdata/full: loading package timed out after 1ns: context deadline exceeded\n"+
+				"multimatch.Flag in ./testdata/multimatch: loading package timed out after 1ns: context deadline exceeded\n",
+			results.String(),
+		)
+	})
+}
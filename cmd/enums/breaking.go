@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/gaqzi/enums"
+)
+
+// breaking compares the enums in the current checkout to a published
+// version of the same module, like apidiff but for enum values.
+func breaking(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("breaking", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typ := fs.String("type", "", "the enum type to compare, e.g. feature.Flag (required)")
+	since := fs.String("since", "", "the published version to compare against, e.g. v1.4.0 (required)")
+	module := fs.String("module", "", "the module path, defaults to the module of the package")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	if *typ == "" || *since == "" {
+		fmt.Fprintln(stderr, "enums breaking: -type and -since are required")
+		fs.Usage()
+		return exitInvalid
+	}
+
+	pkg := "."
+	if fs.NArg() > 0 {
+		pkg = fs.Arg(0)
+	}
+
+	mod, rel, err := modulePackage(pkg)
+	if err != nil {
+		fmt.Fprintf(stderr, "enums breaking: failed to find the module of %s: %s\n", pkg, err)
+		return exitInvalid
+	}
+	if *module == "" {
+		*module = mod
+	}
+
+	// The published version is resolved from the root of the module
+	old, err := enums.AllAtVersion(*module, *since, rel, *typ)
+	if err != nil {
+		fmt.Fprintf(stderr, "enums breaking: %s\n", err)
+		return exitInvalid
+	}
+
+	current, err := enums.All(pkg, *typ)
+	if err != nil {
+		fmt.Fprintf(stderr, "enums breaking: %s\n", err)
+		return exitInvalid
+	}
+
+	changes := enums.Breaking(old, current)
+	if len(changes) == 0 {
+		return exitOK
+	}
+
+	fmt.Fprintf(stdout, "Breaking changes to %s since %s:\n", *typ, *since)
+	for _, c := range changes {
+		fmt.Fprintf(stdout, "\t%s\n", c)
+	}
+
+	return exitFailed
+}
+
+// modulePackage returns the path of the module providing the packages of
+// pattern, which in a go.work workspace isn't necessarily the module of the
+// current directory, and pattern relative to the root of the module.
+func modulePackage(pattern string) (module, rel string, err error) {
+	out, err := exec.Command("go", "list", "-f", "{{.ImportPath}}\t{{with .Module}}{{.Path}}\t{{.Dir}}{{end}}", pattern).Output()
+	if err != nil {
+		return "", "", err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return "", "", fmt.Errorf("%s matches no packages", pattern)
+	}
+
+	var dir string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\t")
+		switch {
+		case len(fields) < 3:
+			return "", "", fmt.Errorf("%s is not in a module", fields[0])
+		case module != "" && fields[1] != module:
+			return "", "", fmt.Errorf("%s matches packages in %s and %s", pattern, module, fields[1])
+		}
+		module, dir = fields[1], fields[2]
+	}
+
+	if !filepath.IsAbs(pattern) && !strings.HasPrefix(pattern, ".") {
+		// An import path, such as example.com/app/feature/...
+		if pattern == module {
+			return module, ".", nil
+		}
+		return module, "./" + strings.TrimPrefix(pattern, module+"/"), nil
+	}
+
+	abs, err := filepath.Abs(pattern)
+	if err != nil {
+		return "", "", err
+	}
+	if rel, err = filepath.Rel(dir, abs); err != nil {
+		return "", "", err
+	}
+	if rel == "." {
+		return module, ".", nil
+	}
+
+	return module, "./" + filepath.ToSlash(rel), nil
+}
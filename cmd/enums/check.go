@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gaqzi/enums"
+)
+
+// config is the file listing the checks run by the check command, so the
+// enum policies of a repository are declared once.
+//
+// Example:
+//
+//	checks:
+//	  - type: feature.Flag
+//	    package: ./feature
+//	    baseline: web/flags.json
+//	    format: vet
+//	    exclude: [FlagTestOnly]
+type config struct {
+	Checks []checkConfig `yaml:"checks"`
+}
+
+// checkConfig is a diff of the enums of a type against a baseline, with
+// the flags of the diff command. Paths are relative to the config file.
+type checkConfig struct {
+	Type       string   `yaml:"type"`
+	Package    string   `yaml:"package"`     // defaults to the directory of the config file
+	Baseline   string   `yaml:"baseline"`    // a JSON array of the handled values
+	Format     string   `yaml:"format"`      // text, unified, or vet, defaults to text
+	Color      bool     `yaml:"color"`       // color the unified format
+	IgnoreCase bool     `yaml:"ignore-case"` // compare the values ignoring case
+	Exclude    []string `yaml:"exclude"`     // the names of enums that don't have to be in the baseline
+}
+
+// check runs every check in a config file and fails if any of them found
+// differences.
+func check(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("config", ".enums.yaml", "the config file listing the checks")
+	webhook := fs.String("webhook", "", "a Slack compatible webhook URL to post a summary of the differences to")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	cfg, err := readConfig(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "enums check: %s\n", err)
+		return exitInvalid
+	}
+
+	dir := filepath.Dir(*file)
+	var checker enums.Checker
+	for _, c := range cfg.Checks {
+		values, err := readBaseline(filepath.Join(dir, c.Baseline))
+		if err != nil {
+			fmt.Fprintf(stderr, "enums check: %s: %s\n", c.Type, err)
+			return exitInvalid
+		}
+
+		// Relative to the config file rather than the current directory
+		pkg := c.Package
+		if strings.HasPrefix(pkg, ".") {
+			if pkg, err = filepath.Abs(filepath.Join(dir, pkg)); err != nil {
+				fmt.Fprintf(stderr, "enums check: %s: %s\n", c.Type, err)
+				return exitInvalid
+			}
+		}
+		checker.Checks = append(checker.Checks, enums.Check{
+			Package: pkg,
+			Type:    c.Type,
+			Actual:  values,
+			Options: enums.DiffOptions{CaseInsensitive: c.IgnoreCase},
+			Exclude: c.Exclude,
+		})
+	}
+
+	code := exitOK
+	var summary strings.Builder
+	for i, res := range checker.Run() {
+		c := cfg.Checks[i]
+		switch {
+		case res.Err != nil:
+			fmt.Fprintf(stderr, "enums check: %s: %s\n", c.Type, res.Err)
+			return exitInvalid
+		case res.Diff.Zero():
+			continue
+		}
+
+		if c.Format != "vet" {
+			fmt.Fprintf(stdout, "%s differs from %s:\n", c.Type, c.Baseline)
+		}
+		writeDiff(stdout, res.Diff, c.Format, c.Color, filepath.Join(dir, c.Baseline))
+		fmt.Fprintf(&summary, "%s differs from %s:\n%s", c.Type, c.Baseline, res.Diff)
+		code = exitFailed
+	}
+
+	if *webhook != "" && summary.Len() > 0 {
+		if err := notify(*webhook, "enums check found differences:\n```\n"+summary.String()+"```"); err != nil {
+			fmt.Fprintf(stderr, "enums check: %s\n", err)
+		}
+	}
+
+	return code
+}
+
+// notify posts text to a webhook in the payload of Slack's incoming
+// webhooks, which Mattermost, Discord's /slack endpoint, and others accept.
+func notify(url, text string) error {
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post to the webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to post to the webhook: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// readConfig reads and validates the config file.
+func readConfig(file string) (config, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return config{}, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return config{}, fmt.Errorf("config %s is invalid: %w", file, err)
+	}
+
+	for i, c := range cfg.Checks {
+		switch {
+		case c.Type == "":
+			return config{}, fmt.Errorf("config %s: check %d has no type", file, i+1)
+		case c.Baseline == "":
+			return config{}, fmt.Errorf("config %s: check %s has no baseline", file, c.Type)
+		case c.Format != "" && c.Format != "text" && c.Format != "unified" && c.Format != "vet":
+			return config{}, fmt.Errorf("config %s: check %s has unknown format %q, one of: text, unified, vet", file, c.Type, c.Format)
+		}
+		if c.Package == "" {
+			cfg.Checks[i].Package = "."
+		}
+	}
+
+	return cfg, nil
+}
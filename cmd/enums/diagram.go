@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gaqzi/enums"
+)
+
+// diagram writes a Mermaid or Graphviz graph of enum types, their values, and
+// the code handling them, for architecture documentation.
+func diagram(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("diagram", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var types stringsFlag
+	fs.Var(&types, "type", "an enum type to include, e.g. feature.Flag, can be repeated (required)")
+	format := fs.String("format", "mermaid", "the output format, one of: mermaid, dot")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	if len(types) == 0 {
+		fmt.Fprintln(stderr, "enums diagram: -type is required")
+		fs.Usage()
+		return exitInvalid
+	}
+
+	write := enums.WriteMermaid
+	switch *format {
+	case "mermaid":
+	case "dot":
+		write = enums.WriteDOT
+	default:
+		fmt.Fprintf(stderr, "enums diagram: unknown format %q, one of: mermaid, dot\n", *format)
+		return exitInvalid
+	}
+
+	pkg := "."
+	if fs.NArg() > 0 {
+		pkg = fs.Arg(0)
+	}
+
+	matrices := make([]enums.CoverageMatrix, 0, len(types))
+	for _, typ := range types {
+		matrix, err := enums.Coverage(pkg, typ)
+		if err != nil {
+			fmt.Fprintf(stderr, "enums diagram: %s\n", err)
+			return exitInvalid
+		}
+		matrices = append(matrices, matrix)
+	}
+
+	if err := write(stdout, matrices...); err != nil {
+		fmt.Fprintf(stderr, "enums diagram: %s\n", err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
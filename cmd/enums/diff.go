@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gaqzi/enums"
+)
+
+// diff compares the enums of a type to the values listed in a baseline file,
+// so code that isn't written in Go, like a frontend consuming a generated
+// client, can be checked for handling every value.
+func diff(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typ := fs.String("type", "", "the enum type to compare, e.g. feature.Flag (required)")
+	baseline := fs.String("baseline", "", `a JSON file with an array of the handled values, e.g. ["flag-a", "flag-b"] (required)`)
+	format := fs.String("format", "text", "the output format, text, unified for -/+ lines, or vet for file:line:col: messages editors can jump to")
+	color := fs.Bool("color", false, "color the unified format with ANSI escape codes")
+	ignoreCase := fs.Bool("ignore-case", false, "compare the values ignoring case, for baselines from systems that normalize case")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	if *format != "text" && *format != "unified" && *format != "vet" {
+		fmt.Fprintf(stderr, "enums diff: unknown format %q, one of: text, unified, vet\n", *format)
+		return exitInvalid
+	}
+
+	if *baseline == "" {
+		fmt.Fprintln(stderr, "enums diff: -baseline is required")
+		fs.Usage()
+		return exitInvalid
+	}
+
+	values, err := readBaseline(*baseline)
+	if err != nil {
+		fmt.Fprintf(stderr, "enums diff: %s\n", err)
+		return exitInvalid
+	}
+
+	collection, code := scan(fs, *typ, stderr)
+	if code != exitOK {
+		return code
+	}
+
+	d := collection.DiffWith(values, enums.DiffOptions{CaseInsensitive: *ignoreCase})
+	if d.Zero() {
+		return exitOK
+	}
+
+	writeDiff(stdout, d, *format, *color, *baseline)
+	return exitFailed
+}
+
+// writeDiff writes d to w in format, one of text, unified, or vet, for the
+// values read from baseline.
+func writeDiff(w io.Writer, d enums.Diff, format string, color bool, baseline string) {
+	switch format {
+	case "vet":
+		fmt.Fprint(w, d.Vet(baseline))
+	case "unified":
+		fmt.Fprint(w, d.Unified(color))
+	default:
+		fmt.Fprint(w, d)
+	}
+}
+
+// readBaseline reads the values from a JSON array of strings in file.
+func readBaseline(file string) ([]string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var values []string
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("baseline %s is not a JSON array of strings: %w", file, err)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gaqzi/enums"
+)
+
+// gen writes code generated from the enums of a type to stdout.
+func gen(args []string, stdout, stderr io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprintln(stderr, "enums gen: missing generator, one of: switch, test, set, doc, kubebuilder")
+		return exitInvalid
+	}
+
+	switch args[0] {
+	case "switch":
+		return genSwitch(args[1:], stdout, stderr)
+	case "test":
+		return genTest(args[1:], stdout, stderr)
+	case "set":
+		return genSet(args[1:], stdout, stderr)
+	case "doc":
+		return genDoc(args[1:], stdout, stderr)
+	case "kubebuilder":
+		return genKubebuilder(args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "enums gen: unknown generator %q, one of: switch, test, set, doc, kubebuilder\n", args[0])
+		return exitInvalid
+	}
+}
+
+func genSwitch(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gen switch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typ := fs.String("type", "", "the enum type to switch over, e.g. feature.Flag (required)")
+	v := fs.String("var", "v", "the name of the variable to switch on")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	collection, code := scan(fs, *typ, stderr)
+	if code != exitOK {
+		return code
+	}
+
+	if err := enums.WriteSwitch(stdout, collection, *v); err != nil {
+		fmt.Fprintf(stderr, "enums gen switch: %s\n", err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+func genTest(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gen test", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typ := fs.String("type", "", "the enum type to write a test for, e.g. feature.Flag (required)")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	collection, code := scan(fs, *typ, stderr)
+	if code != exitOK {
+		return code
+	}
+
+	if err := enums.WriteTestSkeleton(stdout, collection); err != nil {
+		fmt.Fprintf(stderr, "enums gen test: %s\n", err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+func genSet(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gen set", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typ := fs.String("type", "", "the enum type to write a set type for, e.g. feature.Flag (required)")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	collection, code := scan(fs, *typ, stderr)
+	if code != exitOK {
+		return code
+	}
+
+	if err := enums.WriteSet(stdout, collection); err != nil {
+		fmt.Fprintf(stderr, "enums gen set: %s\n", err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+func genDoc(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gen doc", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typ := fs.String("type", "", "the enum type to list the values of, e.g. feature.Flag (required)")
+	fn := fs.String("func", "", "the function to list the values in the doc comment of, e.g. AllFlags (required)")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	if *fn == "" {
+		fmt.Fprintln(stderr, "enums gen doc: -func is required")
+		fs.Usage()
+		return exitInvalid
+	}
+
+	collection, code := scan(fs, *typ, stderr)
+	if code != exitOK {
+		return code
+	}
+
+	// The function is looked for in the packages the values are declared in
+	tried := make(map[string]bool)
+	for _, e := range collection.Enums {
+		dir := filepath.Dir(e.Pos.Filename)
+		if tried[dir] {
+			continue
+		}
+		tried[dir] = true
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			fmt.Fprintf(stderr, "enums gen doc: %s\n", err)
+			return exitInvalid
+		}
+
+		for _, file := range files {
+			src, err := os.ReadFile(file)
+			if err != nil {
+				fmt.Fprintf(stderr, "enums gen doc: %s\n", err)
+				return exitInvalid
+			}
+			if strings.HasSuffix(file, "_test.go") || !bytes.Contains(src, []byte("func "+*fn+"(")) {
+				continue
+			}
+
+			patched, err := enums.PatchDocComment(src, *fn, collection)
+			if err != nil {
+				fmt.Fprintf(stderr, "enums gen doc: %s: %s\n", file, err)
+				return exitInvalid
+			}
+
+			if err := os.WriteFile(file, patched, 0o644); err != nil {
+				fmt.Fprintf(stderr, "enums gen doc: %s\n", err)
+				return exitInvalid
+			}
+
+			return exitOK
+		}
+	}
+
+	fmt.Fprintf(stderr, "enums gen doc: function %s isn't declared in the package of %s\n", *fn, *typ)
+	return exitInvalid
+}
+
+func genKubebuilder(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gen kubebuilder", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typ := fs.String("type", "", "the enum type to write a validation marker for, e.g. v1.Phase (required)")
+	write := fs.Bool("w", false, "add or replace the marker on the type in its source file instead of writing it to stdout")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	collection, code := scan(fs, *typ, stderr)
+	if code != exitOK {
+		return code
+	}
+
+	if !*write {
+		if err := enums.WriteKubebuilderMarker(stdout, collection); err != nil {
+			fmt.Fprintf(stderr, "enums gen kubebuilder: %s\n", err)
+			return exitInvalid
+		}
+
+		return exitOK
+	}
+
+	// The type is most often declared in the same file as its values
+	tried := make(map[string]bool)
+	for _, e := range collection.Enums {
+		file := e.Pos.Filename
+		if tried[file] {
+			continue
+		}
+		tried[file] = true
+
+		src, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "enums gen kubebuilder: %s\n", err)
+			return exitInvalid
+		}
+
+		patched, err := enums.PatchKubebuilderMarker(src, collection)
+		if err != nil {
+			continue
+		}
+
+		if err := os.WriteFile(file, patched, 0o644); err != nil {
+			fmt.Fprintf(stderr, "enums gen kubebuilder: %s\n", err)
+			return exitInvalid
+		}
+
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "enums gen kubebuilder: %s isn't declared in the same file as any of its values, add the marker by hand\n", *typ)
+	return exitInvalid
+}
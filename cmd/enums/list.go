@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/gaqzi/enums"
+)
+
+// formats are the output formats of the list command.
+var formats = []string{"text", "json", "csv", "markdown", "go", "avro", "template"}
+
+// list writes the enums of a type in one of several formats, so a single
+// scan can feed documentation, spreadsheets, and code reviews.
+func list(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typ := fs.String("type", "", "the enum type to list, e.g. feature.Flag (required)")
+	format := fs.String("format", "text", "the output format, one of: "+strings.Join(formats, ", "))
+	tmpl := fs.String("template", "", "the text/template executed with the enums.Collection for -format template, see enums.Collection.Execute for the data")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	var t *template.Template
+	switch *format {
+	case "text", "json", "csv", "markdown", "go", "avro":
+	case "template":
+		if *tmpl == "" {
+			fmt.Fprintln(stderr, "enums list: -template is required for -format template")
+			return exitInvalid
+		}
+
+		var err error
+		if t, err = template.New("list").Parse(*tmpl); err != nil {
+			fmt.Fprintf(stderr, "enums list: %s\n", err)
+			return exitInvalid
+		}
+	default:
+		fmt.Fprintf(stderr, "enums list: unknown format %q, one of: %s\n", *format, strings.Join(formats, ", "))
+		return exitInvalid
+	}
+
+	collection, code := scan(fs, *typ, stderr)
+	if code != exitOK {
+		return code
+	}
+
+	if err := writeList(stdout, collection, *format, t); err != nil {
+		fmt.Fprintf(stderr, "enums list: %s\n", err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+// writeList writes the enums of c to w in format, t is only used for the template format.
+func writeList(w io.Writer, c enums.Collection, format string, t *template.Template) error {
+	switch format {
+	case "text":
+		for _, e := range c.Enums {
+			if _, err := fmt.Fprintf(w, "%s = %s\n", e.Name, e.Value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(c)
+	case "csv":
+		return c.WriteCSV(w)
+	case "markdown":
+		var b strings.Builder
+		b.WriteString("| Name | Value |\n| --- | --- |\n")
+		for _, e := range c.Enums {
+			fmt.Fprintf(&b, "| %s | `%s` |\n", e.Name, strings.ReplaceAll(e.Value, "|", `\|`))
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	case "go":
+		return enums.WriteSlice(w, c)
+	case "avro":
+		return enums.WriteAvroSchema(w, c)
+	case "template":
+		return c.Execute(t, w)
+	default:
+		return errors.New("unknown format " + format)
+	}
+}
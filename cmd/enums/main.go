@@ -0,0 +1,112 @@
+// Command enums runs checks on the enums declared in Go packages from the
+// command line, for use in CI or in repositories that aren't written in Go.
+//
+// Usage:
+//
+//	enums <command> [flags] [package]
+//
+// The commands are:
+//
+//	breaking   fail if enums were removed or changed since a published version
+//	check      run every diff listed in a config file, .enums.yaml by default
+//	diagram    write a mermaid or dot graph of enum types and the code handling them
+//	diff       fail if the values in a baseline file don't match the enums
+//	gen        generate code from the enums of a type
+//	list       write the enums of a type as text, json, csv, markdown, go, avro, or a template
+//	report     write an HTML page listing the enums of several types
+//	stats      write the number of enums, deprecated, and unused enums as JSON
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gaqzi/enums"
+)
+
+// Exit codes used by all commands.
+const (
+	exitOK      = 0 // the check passed
+	exitFailed  = 1 // the check found problems
+	exitInvalid = 2 // the command couldn't run, bad flags or failing to load
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) < 1 {
+		usage(stderr)
+		return exitInvalid
+	}
+
+	switch args[0] {
+	case "breaking":
+		return breaking(args[1:], stdout, stderr)
+	case "check":
+		return check(args[1:], stdout, stderr)
+	case "diagram":
+		return diagram(args[1:], stdout, stderr)
+	case "diff":
+		return diff(args[1:], stdout, stderr)
+	case "gen":
+		return gen(args[1:], stdout, stderr)
+	case "list":
+		return list(args[1:], stdout, stderr)
+	case "report":
+		return report(args[1:], stdout, stderr)
+	case "stats":
+		return stats(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "enums: unknown command %q\n", args[0])
+		usage(stderr)
+		return exitInvalid
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprint(w, `Usage: enums <command> [flags] [package]
+
+Commands:
+  breaking   fail if enums were removed or changed since a published version
+  check      run every diff listed in a config file, .enums.yaml by default
+  diagram    write a mermaid or dot graph of enum types and the code handling them
+  diff       fail if the values in a baseline file don't match the enums
+  gen        generate code from the enums of a type, generators: switch, test, set, doc, kubebuilder
+  list       write the enums of a type as text, json, csv, markdown, go, avro, or a template
+  report     write an HTML page listing the enums of several types
+  stats      write the number of enums, deprecated, and unused enums as JSON
+
+Run "enums <command> -h" for the flags of a command.
+`)
+}
+
+// scan finds the enums of typ in the package given as the only argument to
+// fs, defaulting to the current directory. On failure the returned exit code
+// is not exitOK and the reason has been written to stderr.
+func scan(fs *flag.FlagSet, typ string, stderr io.Writer) (enums.Collection, int) {
+	if typ == "" {
+		fmt.Fprintf(stderr, "enums %s: -type is required\n", fs.Name())
+		fs.Usage()
+		return enums.Collection{}, exitInvalid
+	}
+
+	pkg := "."
+	if fs.NArg() > 0 {
+		pkg = fs.Arg(0)
+	}
+
+	collection, err := enums.All(pkg, typ)
+	if err != nil {
+		fmt.Fprintf(stderr, "enums %s: %s\n", fs.Name(), err)
+		return enums.Collection{}, exitInvalid
+	}
+
+	return collection, exitOK
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums/internal/goproxy"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("prints usage and fails without a command", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitInvalid, run(nil, &stdout, &stderr))
+		require.Contains(t, stderr.String(), "Usage: enums")
+	})
+
+	t.Run("fails on unknown commands", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitInvalid, run([]string{"nope"}, &stdout, &stderr))
+		require.Contains(t, stderr.String(), `unknown command "nope"`)
+	})
+
+	t.Run("breaking requires -type and -since", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitInvalid, run([]string{"breaking", "-type", "full.Flag"}, &stdout, &stderr))
+		require.Contains(t, stderr.String(), "-type and -since are required")
+	})
+
+	t.Run("diff passes when the baseline has every value", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		baseline := writeFile(t, `["deploy-all-the-things", "deploy-one-thing"]`)
+
+		require.Equal(t, exitOK, run([]string{"diff", "-type", "full.Flag", "-baseline", baseline, "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Empty(t, stdout.String())
+	})
+
+	t.Run("diff fails and prints the differences", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		baseline := writeFile(t, `["deploy-all-the-things", "deploy-nothing"]`)
+
+		require.Equal(t, exitFailed, run([]string{"diff", "-type", "full.Flag", "-baseline", baseline, "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Equal(
+			t,
+			"Enums declared but not part of actual:\n\tDeployOneThing = \"deploy-one-thing\" (declared at testdata/full/example.go:7)\nExtra values provided but not part of Enums:\n\t\"deploy-nothing\"\n",
+			stdout.String(),
+		)
+	})
+
+	t.Run("diff prints the position of missing enums with -format vet", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		baseline := writeFile(t, `["deploy-all-the-things"]`)
+		file, err := filepath.Abs("../../testdata/full/example.go")
+		require.NoError(t, err)
+
+		require.Equal(t, exitFailed, run([]string{"diff", "-type", "full.Flag", "-baseline", baseline, "-format", "vet", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Equal(t, file+`:7:2: Flag value "deploy-one-thing" not handled in `+baseline+"\n", stdout.String())
+	})
+
+	t.Run("diff prints -/+ lines with -format unified", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		baseline := writeFile(t, `["deploy-all-the-things", "deploy-nothing"]`)
+
+		require.Equal(t, exitFailed, run([]string{"diff", "-type", "full.Flag", "-baseline", baseline, "-format", "unified", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Equal(t, "--- declared\n+++ actual\n-DeployOneThing = \"deploy-one-thing\"\n+\"deploy-nothing\"\n", stdout.String())
+	})
+
+	t.Run("diff ignores the case of the values with -ignore-case", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		baseline := writeFile(t, `["DEPLOY-ALL-THE-THINGS", "Deploy-One-Thing"]`)
+
+		require.Equal(t, exitOK, run([]string{"diff", "-type", "full.Flag", "-baseline", baseline, "-ignore-case", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Empty(t, stdout.String())
+	})
+
+	t.Run("diff fails on a baseline that isn't an array of strings", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		baseline := writeFile(t, `{"deploy-one-thing": true}`)
+
+		require.Equal(t, exitInvalid, run([]string{"diff", "-type", "full.Flag", "-baseline", baseline, "../../testdata/full"}, &stdout, &stderr))
+		require.Contains(t, stderr.String(), "is not a JSON array of strings")
+	})
+
+	t.Run("list writes the enums in the format", func(t *testing.T) {
+		for format, expected := range map[string]string{
+			"text":     "DeployAllTheThings = \"deploy-all-the-things\"\nDeployOneThing = \"deploy-one-thing\"\n",
+			"csv":      "name,value,file,line,doc\nDeployAllTheThings,\"\"\"deploy-all-the-things\"\"\",testdata/full/example.go,6,\nDeployOneThing,\"\"\"deploy-one-thing\"\"\",testdata/full/example.go,7,\n",
+			"markdown": "| Name | Value |\n| --- | --- |\n| DeployAllTheThings | `\"deploy-all-the-things\"` |\n| DeployOneThing | `\"deploy-one-thing\"` |\n",
+			"go":       "[]full.Flag{\n\tfull.DeployAllTheThings,\n\tfull.DeployOneThing,\n}\n",
+		} {
+			t.Run(format, func(t *testing.T) {
+				var stdout, stderr bytes.Buffer
+
+				require.Equal(t, exitOK, run([]string{"list", "-type", "full.Flag", "-format", format, "../../testdata/full"}, &stdout, &stderr), stderr.String())
+				require.Equal(t, expected, stdout.String())
+			})
+		}
+
+		t.Run("json", func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			require.Equal(t, exitOK, run([]string{"list", "-type", "full.Flag", "--format", "json", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+			require.Contains(t, stdout.String(), `"Name": "DeployOneThing",`)
+		})
+
+		t.Run("template", func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			require.Equal(t, exitOK, run([]string{"list", "-type", "full.Flag", "-format", "template", "-template", "{{range .Enums}}{{.Name}};{{end}}", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+			require.Equal(t, "DeployAllTheThings;DeployOneThing;", stdout.String())
+		})
+	})
+
+	t.Run("list fails on unknown formats", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitInvalid, run([]string{"list", "-type", "full.Flag", "-format", "yaml"}, &stdout, &stderr))
+		require.Contains(t, stderr.String(), `unknown format "yaml"`)
+	})
+
+	t.Run("report writes an HTML page with every type", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitOK, run([]string{"report", "-type", "full.Flag", "-type", "full.FlagStruct", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Contains(t, stdout.String(), ">Flag</h3>")
+		require.Contains(t, stdout.String(), ">FlagStruct</h3>")
+	})
+
+	t.Run("report requires -type", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitInvalid, run([]string{"report"}, &stdout, &stderr))
+		require.Contains(t, stderr.String(), "enums report: -type is required")
+	})
+
+	t.Run("check runs every check in the config file", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		full, err := filepath.Abs("../../testdata/full")
+		require.NoError(t, err)
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "flags.json"), []byte(`["deploy-all-the-things"]`), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "structs.json"), []byte(`[]`), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".enums.yaml"), []byte(`checks:
+  - type: full.Flag
+    package: `+full+`
+    baseline: flags.json
+    exclude: [DeployOneThing]
+  - type: full.FlagStruct
+    package: `+full+`
+    baseline: structs.json
+`), 0o600))
+
+		require.Equal(t, exitFailed, run([]string{"check", "-config", filepath.Join(dir, ".enums.yaml")}, &stdout, &stderr), stderr.String())
+		require.Equal(
+			t,
+			"full.FlagStruct differs from structs.json:\n"+
+				"Enums declared but not part of actual:\n"+
+				"\tFlagDefaultOn = \"flag-default-on\" (declared at testdata/full/example_struct.go:9)\n",
+			stdout.String(),
+			"the excluded enum isn't missing",
+		)
+	})
+
+	t.Run("check posts the differences to -webhook", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		var payload map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		}))
+		defer server.Close()
+
+		full, err := filepath.Abs("../../testdata/full")
+		require.NoError(t, err)
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "flags.json"), []byte(`["deploy-all-the-things"]`), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".enums.yaml"), []byte("checks:\n  - type: full.Flag\n    package: "+full+"\n    baseline: flags.json\n"), 0o600))
+
+		require.Equal(t, exitFailed, run([]string{"check", "-config", filepath.Join(dir, ".enums.yaml"), "-webhook", server.URL}, &stdout, &stderr), stderr.String())
+		require.Equal(
+			t,
+			"enums check found differences:\n```\n"+
+				"full.Flag differs from flags.json:\n"+
+				"Enums declared but not part of actual:\n"+
+				"\tDeployOneThing = \"deploy-one-thing\" (declared at testdata/full/example.go:7)\n"+
+				"```",
+			payload["text"],
+		)
+	})
+
+	t.Run("check fails on a config with unknown formats", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		config := filepath.Join(t.TempDir(), ".enums.yaml")
+		require.NoError(t, os.WriteFile(config, []byte("checks:\n  - type: full.Flag\n    baseline: flags.json\n    format: yaml\n"), 0o600))
+
+		require.Equal(t, exitInvalid, run([]string{"check", "-config", config}, &stdout, &stderr))
+		require.Contains(t, stderr.String(), `check full.Flag has unknown format "yaml"`)
+	})
+
+	t.Run("diagram writes a graph of the types and the sites handling them", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitOK, run([]string{"diagram", "-type", "coverage.Flag", "-format", "dot", "../../testdata/coverage"}, &stdout, &stderr), stderr.String())
+		require.Contains(t, stdout.String(), `s2 [label="handle switch", shape=box];`)
+	})
+
+	t.Run("diagram fails on unknown formats", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitInvalid, run([]string{"diagram", "-type", "coverage.Flag", "-format", "svg"}, &stdout, &stderr))
+		require.Contains(t, stderr.String(), `unknown format "svg"`)
+	})
+
+	t.Run("stats writes the counts as JSON", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitOK, run([]string{"stats", "-type", "stats.Flag", "../../testdata/stats"}, &stdout, &stderr), stderr.String())
+		require.Contains(t, stdout.String(), `"deprecated": 1,`)
+		require.Contains(t, stdout.String(), `"unused": [
+        "FlagLegacy"
+      ]`)
+	})
+
+	t.Run("gen switch writes a switch for the type", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitOK, run([]string{"gen", "switch", "-type", "full.Flag", "-var", "flag", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Contains(t, stdout.String(), "switch flag {\ncase DeployAllTheThings:\n")
+	})
+
+	t.Run("gen switch requires -type", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitInvalid, run([]string{"gen", "switch"}, &stdout, &stderr))
+		require.Contains(t, stderr.String(), "enums gen switch: -type is required")
+	})
+
+	t.Run("gen kubebuilder writes the validation marker", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitOK, run([]string{"gen", "kubebuilder", "-type", "full.Flag", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Equal(t, "// +kubebuilder:validation:Enum=deploy-all-the-things;deploy-one-thing\n", stdout.String())
+	})
+
+	t.Run("gen doc lists the values in the doc comment of the function", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/feature\n\ngo 1.25.0\n"), 0o600))
+		src := "package feature\n\ntype Flag string\n\nconst FlagA Flag = \"a\"\n\n// AllFlags returns every flag.\nfunc AllFlags() []Flag { return []Flag{FlagA} }\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "flag.go"), []byte(src), 0o600))
+
+		require.Equal(t, exitOK, run([]string{"gen", "doc", "-type", "feature.Flag", "-func", "AllFlags", dir}, &stdout, &stderr), stderr.String())
+
+		patched, err := os.ReadFile(filepath.Join(dir, "flag.go"))
+		require.NoError(t, err)
+		require.Contains(t, string(patched), "// AllFlags returns every flag.\n//\n// Values:\n//   - FlagA = \"a\"\nfunc AllFlags()")
+	})
+
+	t.Run("gen set writes a set type for the type", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitOK, run([]string{"gen", "set", "-type", "full.Flag", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Contains(t, stdout.String(), "type FlagSet map[Flag]struct{}\n")
+	})
+
+	t.Run("gen test writes a test skeleton for the type", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitOK, run([]string{"gen", "test", "-type", "full.Flag", "../../testdata/full"}, &stdout, &stderr), stderr.String())
+		require.Contains(t, stdout.String(), "func TestFlag(t *testing.T) {\n")
+	})
+}
+
+// writeFile writes content to a temporary file and returns its path.
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	return file
+}
+
+func TestBreaking(t *testing.T) {
+	proxy := goproxy.Publish(t, "../../testdata/published", "example.com/published", "v1.0.0")
+	t.Setenv("GOPROXY", "file://"+proxy)
+	t.Setenv("GOSUMDB", "off")
+	t.Setenv("GOMODCACHE", t.TempDir())
+	t.Setenv("GOFLAGS", "-modcacherw") // so the test can remove the module cache
+
+	// checkout copies the published module and runs the test from its feature package
+	checkout := func(t *testing.T, flag string) {
+		t.Helper()
+
+		dir := t.TempDir()
+		require.NoError(t, os.CopyFS(dir, os.DirFS("../../testdata/published")))
+		if flag != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "feature", "flag.go"), []byte(flag), 0o600))
+		}
+		t.Chdir(filepath.Join(dir, "feature"))
+	}
+
+	t.Run("passes without changes since the version", func(t *testing.T) {
+		checkout(t, "")
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitOK, run([]string{"breaking", "-type", "feature.Flag", "-since", "v1.0.0"}, &stdout, &stderr), stderr.String())
+		require.Empty(t, stdout.String())
+	})
+
+	t.Run("fails and prints the enums removed since the version", func(t *testing.T) {
+		checkout(t, "package feature\n\ntype Flag string\n\nconst FlagOn Flag = \"on\"\n")
+		var stdout, stderr bytes.Buffer
+
+		require.Equal(t, exitFailed, run([]string{"breaking", "-type", "feature.Flag", "-since", "v1.0.0", "."}, &stdout, &stderr), stderr.String())
+		require.Equal(t, "Breaking changes to feature.Flag since v1.0.0:\n\tFlagOld = \"old\": removed\n", stdout.String())
+	})
+}
+
+func TestModulePackage(t *testing.T) {
+	t.Run("returns the module of the package in a workspace relative to its root", func(t *testing.T) {
+		t.Setenv("GOFLAGS", "")
+		t.Chdir("../../testdata/workspace")
+
+		module, rel, err := modulePackage("./billing")
+		require.NoError(t, err)
+
+		require.Equal(t, "example.com/billing", module)
+		require.Equal(t, ".", rel)
+	})
+
+	t.Run("returns import paths relative to the root of the module", func(t *testing.T) {
+		module, rel, err := modulePackage("github.com/gaqzi/enums/policy/...")
+		require.NoError(t, err)
+
+		require.Equal(t, "github.com/gaqzi/enums", module)
+		require.Equal(t, "./policy/...", rel)
+	})
+
+	t.Run("returns directories relative to the root of the module", func(t *testing.T) {
+		module, rel, err := modulePackage("../../testdata/full")
+		require.NoError(t, err)
+
+		require.Equal(t, "github.com/gaqzi/enums", module)
+		require.Equal(t, "./testdata/full", rel)
+	})
+
+	t.Run("fails when the packages are in different modules", func(t *testing.T) {
+		t.Setenv("GOFLAGS", "")
+		t.Chdir("../../testdata/workspace")
+
+		_, _, err := modulePackage("example.com/...")
+		require.ErrorContains(t, err, "matches packages in example.com/billing and example.com/orders")
+	})
+}
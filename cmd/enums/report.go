@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gaqzi/enums"
+)
+
+// report writes an HTML page listing the enums of several types, to be
+// published from CI as an inventory of the enums in a module.
+func report(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var types stringsFlag
+	fs.Var(&types, "type", "an enum type to include, e.g. feature.Flag, can be repeated (required)")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	if len(types) == 0 {
+		fmt.Fprintln(stderr, "enums report: -type is required")
+		fs.Usage()
+		return exitInvalid
+	}
+
+	pkg := "."
+	if fs.NArg() > 0 {
+		pkg = fs.Arg(0)
+	}
+
+	set, err := enums.AllTypes(pkg, types)
+	if err != nil {
+		fmt.Fprintf(stderr, "enums report: %s\n", err)
+		return exitInvalid
+	}
+
+	collections := make([]enums.Collection, 0, len(types))
+	for _, typ := range types {
+		collections = append(collections, set[typ])
+	}
+
+	if err := enums.WriteHTMLReport(stdout, collections...); err != nil {
+		fmt.Fprintf(stderr, "enums report: %s\n", err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+// stringsFlag is a flag that can be given several times.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
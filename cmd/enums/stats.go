@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gaqzi/enums"
+)
+
+// stats writes the number of enums, deprecated enums, and unused enums of
+// several types as JSON, for tracking flag debt on dashboards over time.
+func stats(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var types stringsFlag
+	fs.Var(&types, "type", "an enum type to count, e.g. feature.Flag, can be repeated (required)")
+	if err := fs.Parse(args); err != nil {
+		return exitInvalid
+	}
+
+	if len(types) == 0 {
+		fmt.Fprintln(stderr, "enums stats: -type is required")
+		fs.Usage()
+		return exitInvalid
+	}
+
+	pkg := "."
+	if fs.NArg() > 0 {
+		pkg = fs.Arg(0)
+	}
+
+	s, err := enums.CollectStats(pkg, types)
+	if err != nil {
+		fmt.Fprintf(stderr, "enums stats: %s\n", err)
+		return exitInvalid
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		fmt.Fprintf(stderr, "enums stats: %s\n", err)
+		return exitInvalid
+	}
+
+	return exitOK
+}
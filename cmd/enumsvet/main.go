@@ -0,0 +1,17 @@
+// Command enumsvet runs the enumsanalyzer on the packages given as
+// arguments, add -fix to apply the suggested fixes.
+//
+// Usage:
+//
+//	enumsvet [-fix] [packages]
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/gaqzi/enums/enumsanalyzer"
+)
+
+func main() {
+	singlechecker.Main(enumsanalyzer.Analyzer)
+}
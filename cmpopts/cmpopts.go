@@ -0,0 +1,165 @@
+// Package cmpopts has go-cmp options and reporters for the types of the enums
+// package, so collections can be compared with cmp.Diff and diffs reported
+// in go-cmp's format.
+package cmpopts
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/gaqzi/enums"
+)
+
+var (
+	enumType       = reflect.TypeFor[enums.Enum]()
+	collectionType = reflect.TypeFor[enums.Collection]()
+)
+
+// IgnoreObjects ignores Enum.Object and Enum.Spec, set with
+// enums.WithObjects, which can't be compared by go-cmp.
+func IgnoreObjects() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		f, ok := p.Last().(cmp.StructField)
+		return ok && p.Index(-2).Type() == enumType && (f.Name() == "Object" || f.Name() == "Spec")
+	}, cmp.Ignore())
+}
+
+// IgnorePositions ignores where the enums are declared, Enum.Pos and
+// Collection.Module, to compare collections scanned from different
+// checkouts or with different load modes.
+func IgnorePositions() cmp.Option {
+	return cmp.Options{
+		cmp.FilterPath(func(p cmp.Path) bool {
+			f, ok := p.Last().(cmp.StructField)
+			return ok && p.Index(-2).Type() == enumType && f.Name() == "Pos"
+		}, cmp.Ignore()),
+		cmp.FilterPath(func(p cmp.Path) bool {
+			f, ok := p.Last().(cmp.StructField)
+			return ok && p.Index(-2).Type() == collectionType && f.Name() == "Module"
+		}, cmp.Ignore()),
+	}
+}
+
+// SortEnums compares the enums of collections by their names, regardless of
+// the order they're in.
+func SortEnums() cmp.Option {
+	return cmp.Transformer("SortEnums", func(es []enums.Enum) []enums.Enum {
+		sorted := append([]enums.Enum(nil), es...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+		return sorted
+	})
+}
+
+// Options is every option in the package, for comparing what was declared
+// in two collections.
+//
+// Example:
+//
+//	cmp.Diff(before, after, cmpopts.Options())
+func Options() cmp.Option {
+	return cmp.Options{IgnoreObjects(), IgnorePositions(), SortEnums()}
+}
+
+// Diff reports the diff of collection against actual, as enums.Collection.Diff
+// calculates it, in go-cmp's format as the sorted values that were declared
+// against the sorted values of actual. An alias is shown as the value of
+// the enum it's an alias of. It's empty when there's no diff.
+//
+// Example:
+//
+//	  []string{
+//	  	"deploy-all-the-things",
+//	- 	"deploy-one-thing",
+//	+ 	"deploy-nothing",
+//	  }
+func Diff(collection enums.Collection, actual interface{}) string {
+	diff := collection.Diff(actual)
+	if diff.Zero() {
+		return ""
+	}
+
+	aliases := make(map[string]bool)
+	for _, e := range collection.Enums {
+		if e.AliasOf() != "" {
+			aliases[e.Name] = true
+		}
+	}
+	missing := make(map[string]bool)
+	for _, e := range diff.Missing.Enums {
+		missing[e.Name] = true
+	}
+
+	var declared, handled []string
+	for _, e := range collection.Enums {
+		if aliases[e.Name] {
+			continue
+		}
+
+		v := e.Unquoted()
+		declared = append(declared, v)
+		if !missing[e.Name] {
+			handled = append(handled, v)
+		}
+	}
+	for _, v := range diff.Extra {
+		handled = append(handled, enums.Unquote(v))
+	}
+	sort.Strings(declared)
+	sort.Strings(handled)
+
+	return cmp.Diff(declared, handled)
+}
+
+// Reporter is a cmp.Reporter collecting every difference with the path to
+// it, for reporting the differences between collections one per line.
+//
+// Example:
+//
+//	var r cmpopts.Reporter
+//	cmp.Equal(before, after, cmpopts.Options(), cmp.Reporter(&r))
+//	fmt.Print(r.String())
+type Reporter struct {
+	path  cmp.Path
+	diffs []string
+}
+
+// PushStep is called by cmp when it steps into a value.
+func (r *Reporter) PushStep(ps cmp.PathStep) {
+	r.path = append(r.path, ps)
+}
+
+// Report is called by cmp with the result of comparing the current value.
+func (r *Reporter) Report(rs cmp.Result) {
+	if rs.Equal() {
+		return
+	}
+
+	vx, vy := r.path.Last().Values()
+	var x, y interface{}
+	if vx.IsValid() {
+		x = vx.Interface()
+	}
+	if vy.IsValid() {
+		y = vy.Interface()
+	}
+	r.diffs = append(r.diffs, fmt.Sprintf("%#v: %#v != %#v", r.path, x, y))
+}
+
+// PopStep is called by cmp when it steps out of a value.
+func (r *Reporter) PopStep() {
+	r.path = r.path[:len(r.path)-1]
+}
+
+// String returns every difference on its own line.
+func (r *Reporter) String() string {
+	if len(r.diffs) == 0 {
+		return ""
+	}
+
+	return strings.Join(r.diffs, "\n") + "\n"
+}
@@ -0,0 +1,48 @@
+package cmpopts_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+	"github.com/gaqzi/enums/cmpopts"
+	"github.com/gaqzi/enums/testdata/full"
+)
+
+func TestOptions(t *testing.T) {
+	t.Run("compares collections regardless of objects, positions, and order", func(t *testing.T) {
+		scanned, err := enums.All("../testdata/full", "full.Flag", enums.WithObjects())
+		require.NoError(t, err)
+		exported, err := enums.All("../testdata/full", "full.Flag", enums.WithExportData())
+		require.NoError(t, err)
+		exported.Enums[0], exported.Enums[1] = exported.Enums[1], exported.Enums[0]
+
+		require.Empty(t, cmp.Diff(scanned, exported, cmpopts.Options()))
+	})
+
+	t.Run("reports the differences between collections", func(t *testing.T) {
+		before := enums.Collection{Type: "feature.Flag", Enums: []enums.Enum{{Name: "FlagA", Value: `"a"`}}}
+		after := enums.Collection{Type: "feature.Flag", Enums: []enums.Enum{{Name: "FlagA", Value: `"b"`}}}
+
+		var r cmpopts.Reporter
+		require.False(t, cmp.Equal(before, after, cmpopts.Options(), cmp.Reporter(&r)))
+		require.Contains(t, r.String(), `.Value: "\"a\"" != "\"b\""`)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	collection, err := enums.All("../testdata/full", "full.Flag")
+	require.NoError(t, err)
+
+	t.Run("is empty when there's no diff", func(t *testing.T) {
+		require.Empty(t, cmpopts.Diff(collection, full.AllFlags()))
+	})
+
+	t.Run("reports the diff in go-cmp's format", func(t *testing.T) {
+		diff := cmpopts.Diff(collection, []full.Flag{full.DeployAllTheThings, "deploy-nothing"})
+
+		require.Equal(t, cmp.Diff([]string{"deploy-all-the-things", "deploy-one-thing"}, []string{"deploy-all-the-things", "deploy-nothing"}), diff)
+	})
+}
@@ -0,0 +1,70 @@
+package enums
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Collision is a value declared by enums in more than one package.
+type Collision struct {
+	Value string // the value as declared by the first of the enums
+	Enums []Enum // the enums declaring the value, ordered by package and name
+}
+
+// String outputs a human summary of the collision.
+func (c Collision) String() string {
+	names := make([]string, len(c.Enums))
+	for i, e := range c.Enums {
+		names[i] = fmt.Sprintf("%s.%s (%s)", path.Base(e.Package), e.Name, e.Pos)
+	}
+
+	return fmt.Sprintf("%s declared in several packages: %s", c.Value, strings.Join(names, ", "))
+}
+
+// Collisions returns the values declared by enums in more than one package,
+// for enums that are the same logically but declared separately, like event
+// types, where a shared value routes to the wrong handler. The enums of all
+// collections are compared, and a single collection from a pattern such as
+// ./... with a short type like EventType covers every package declaring it.
+//
+// Example:
+//
+//	events, _ := All("./...", "EventType")
+//	for _, c := range Collisions(events) {
+//		fmt.Println(c)
+//	}
+func Collisions(collections ...Collection) []Collision {
+	byValue := make(map[string][]Enum)
+	for _, c := range collections {
+		for _, e := range c.Enums {
+			key := e.Unquoted()
+			byValue[key] = append(byValue[key], e)
+		}
+	}
+
+	var collisions []Collision
+	for _, es := range byValue {
+		pkgs := make(map[string]bool)
+		for _, e := range es {
+			pkgs[e.Package] = true
+		}
+		if len(pkgs) < 2 {
+			continue
+		}
+
+		sort.Slice(es, func(i, j int) bool {
+			if es[i].Package != es[j].Package {
+				return es[i].Package < es[j].Package
+			}
+
+			return es[i].Name < es[j].Name
+		})
+		collisions = append(collisions, Collision{Value: es[0].Value, Enums: es})
+	}
+
+	sort.Slice(collisions, func(i, j int) bool { return collisions[i].Value < collisions[j].Value })
+
+	return collisions
+}
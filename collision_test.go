@@ -0,0 +1,60 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestCollisions(t *testing.T) {
+	t.Run("reports values declared in more than one package", func(t *testing.T) {
+		events, err := enums.All("./testdata/collision/...", "EventType")
+		require.NoError(t, err)
+
+		collisions := enums.Collisions(events)
+
+		require.Len(t, collisions, 1)
+		require.Equal(t, `"payment.refunded"`, collisions[0].Value)
+		var types []string
+		for _, e := range collisions[0].Enums {
+			types = append(types, e.Type+" "+e.Name)
+		}
+		require.Equal(
+			t,
+			[]string{
+				"github.com/gaqzi/enums/testdata/collision/billing.EventType EventRefunded",
+				"github.com/gaqzi/enums/testdata/collision/orders.EventType EventRefunded",
+			},
+			types,
+		)
+		require.Regexp(t, `^"payment.refunded" declared in several packages: billing.EventRefunded \(.+/billing/events.go:7:2\), orders.EventRefunded \(.+/orders/events.go:8:2\)$`, collisions[0].String())
+	})
+
+	t.Run("compares the enums of several collections", func(t *testing.T) {
+		billing, err := enums.All("./testdata/collision/billing", "billing.EventType")
+		require.NoError(t, err)
+		orders, err := enums.All("./testdata/collision/orders", "orders.EventType")
+		require.NoError(t, err)
+
+		require.Len(t, enums.Collisions(billing, orders), 1)
+	})
+
+	t.Run("reports values of a shared type declared in more than one package", func(t *testing.T) {
+		events, err := enums.All("./testdata/sharedtype/...", "events.Type")
+		require.NoError(t, err)
+
+		collisions := enums.Collisions(events)
+
+		require.Len(t, collisions, 1)
+		require.Regexp(t, `^"payment.refunded" declared in several packages: billing.EventRefunded \(.+/billing/events.go:5:7\), orders.EventRefunded \(.+/orders/events.go:7:2\)$`, collisions[0].String())
+	})
+
+	t.Run("ignores values declared more than once in the same package", func(t *testing.T) {
+		flags, err := enums.All("./testdata/multimatch", "multimatch.Flag")
+		require.NoError(t, err)
+
+		require.Empty(t, enums.Collisions(flags, flags))
+	})
+}
@@ -0,0 +1,75 @@
+package enums
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Change is an enum that differs between two versions of a Collection.
+type Change struct {
+	Old Enum // the enum as declared in the older collection
+	New Enum // the enum as declared in the newer collection, zero if it was removed
+}
+
+// Removed returns whether the enum no longer exists in the newer collection.
+func (c Change) Removed() bool {
+	return c.New.Name == ""
+}
+
+// Renamed returns whether the value of the enum is kept in the newer
+// collection but declared under a different name.
+func (c Change) Renamed() bool {
+	return !c.Removed() && c.Old.Name != c.New.Name
+}
+
+// String outputs a human summary of the change.
+func (c Change) String() string {
+	if c.Removed() {
+		return fmt.Sprintf("%s = %s: removed", c.Old.Name, c.Old.Value)
+	}
+	if c.Renamed() {
+		return fmt.Sprintf("%s = %s: renamed to %s", c.Old.Name, c.Old.Value, c.New.Name)
+	}
+
+	return fmt.Sprintf("%s = %s: value changed to %s", c.Old.Name, c.Old.Value, c.New.Value)
+}
+
+// Breaking returns the enums of old which have been removed, renamed, or had
+// their value changed in new.
+//
+// Enums are matched by name, and adding new enums is never considered breaking.
+// An enum that is removed while a new enum is added with its value is reported
+// as renamed, since code using the old name no longer compiles even though the
+// value on the wire is the same.
+func Breaking(old, new Collection) []Change {
+	current := make(map[string]Enum, len(new.Enums))
+	for _, e := range new.Enums {
+		current[e.Name] = e
+	}
+
+	previous := make(map[string]bool, len(old.Enums))
+	for _, e := range old.Enums {
+		previous[e.Name] = true
+	}
+	added := make(map[string]Enum)
+	for _, e := range new.Enums {
+		if _, ok := added[e.Value]; !ok && !previous[e.Name] {
+			added[e.Value] = e
+		}
+	}
+
+	var changes []Change
+	for _, e := range old.Enums {
+		n, ok := current[e.Name]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Old: e, New: added[e.Value]})
+		case n.Value != e.Value:
+			changes = append(changes, Change{Old: e, New: n})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Old.Name < changes[j].Old.Name })
+
+	return changes
+}
@@ -0,0 +1,71 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestBreaking(t *testing.T) {
+	old := enums.Collection{
+		Type: "full.Flag",
+		Enums: []enums.Enum{
+			{Name: "FlagChanged", Value: `"flag-changed"`},
+			{Name: "FlagRemoved", Value: `"flag-removed"`},
+			{Name: "FlagSame", Value: `"flag-same"`},
+		},
+	}
+
+	t.Run("no changes when all enums are kept", func(t *testing.T) {
+		require.Empty(t, enums.Breaking(old, old))
+	})
+
+	t.Run("adding enums is not breaking", func(t *testing.T) {
+		current := enums.Collection{Type: "full.Flag", Enums: append([]enums.Enum{{Name: "FlagNew", Value: `"flag-new"`}}, old.Enums...)}
+
+		require.Empty(t, enums.Breaking(old, current))
+	})
+
+	t.Run("returns removed and changed enums", func(t *testing.T) {
+		current := enums.Collection{
+			Type: "full.Flag",
+			Enums: []enums.Enum{
+				{Name: "FlagChanged", Value: `"flag-changed-v2"`},
+				{Name: "FlagSame", Value: `"flag-same"`},
+			},
+		}
+
+		changes := enums.Breaking(old, current)
+
+		require.Equal(
+			t,
+			[]enums.Change{
+				{Old: old.Enums[0], New: current.Enums[0]},
+				{Old: old.Enums[1]},
+			},
+			changes,
+		)
+		require.Equal(t, `FlagChanged = "flag-changed": value changed to "flag-changed-v2"`, changes[0].String())
+		require.Equal(t, `FlagRemoved = "flag-removed": removed`, changes[1].String())
+	})
+
+	t.Run("returns enums whose value is declared under a new name as renamed", func(t *testing.T) {
+		current := enums.Collection{
+			Type: "full.Flag",
+			Enums: []enums.Enum{
+				{Name: "FlagChanged", Value: `"flag-changed"`},
+				{Name: "FlagRenamed", Value: `"flag-removed"`},
+				{Name: "FlagSame", Value: `"flag-same"`},
+			},
+		}
+
+		changes := enums.Breaking(old, current)
+
+		require.Equal(t, []enums.Change{{Old: old.Enums[1], New: current.Enums[1]}}, changes)
+		require.True(t, changes[0].Renamed())
+		require.False(t, changes[0].Removed())
+		require.Equal(t, `FlagRemoved = "flag-removed": renamed to FlagRenamed`, changes[0].String())
+	})
+}
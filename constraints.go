@@ -0,0 +1,196 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// The operating systems and architectures a file name can be constrained
+// to, like file_windows.go or file_linux_arm64.go, as listed by go tool dist list.
+var (
+	knownOS = []string{
+		"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js",
+		"linux", "nacl", "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows", "zos",
+	}
+	knownArch = []string{
+		"386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be", "loong64", "mips",
+		"mipsle", "mips64", "mips64le", "mips64p32", "mips64p32le", "ppc", "ppc64", "ppc64le",
+		"riscv", "riscv64", "s390", "s390x", "sparc", "sparc64", "wasm",
+	}
+)
+
+// collectConstrained adds the enums of typ declared in the files of p that
+// aren't part of the current build. The files aren't type checked, so only
+// declarations with the type written out and a basic literal are found.
+// Enums also declared in the current build aren't added again, and an enum
+// declared in several excluded files has their constraints combined. Files
+// that fail to parse are returned as errors after scanning the others.
+// With onlyCgo only the files excluded for importing "C" are scanned. The
+// enums are filtered like the others, without a declaration as type checked.
+func collectConstrained(c config, collection *Collection, p *packages.Package, typ string, onlyCgo bool) error {
+	if p.Types == nil {
+		return nil
+	}
+	obj, ok := p.Types.Scope().Lookup(typeName(typ)).(*types.TypeName)
+	if !ok || !matchesType(obj.Type(), typ) {
+		return nil
+	}
+
+	declared := make(map[string]int) // the index of the enums of p by name
+	for i, e := range collection.Enums {
+		if e.Package == p.PkgPath {
+			declared[e.Name] = i
+		}
+	}
+
+	var errs []error
+	for _, file := range p.IgnoredFiles {
+		if filepath.Ext(file) != ".go" || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(p.Fset, file, nil, parser.ParseComments)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse %s excluded by build constraints: %w", file, err))
+			continue
+		}
+		if f.Name.Name != p.Name {
+			continue // such as a generator in package main with //go:build ignore
+		}
+		if onlyCgo && !importsC(f) {
+			continue
+		}
+
+		constraints := fileConstraints(f, file)
+		for _, d := range f.Decls {
+			gen, ok := d.(*ast.GenDecl)
+			if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.ValueSpec)
+				if ident, ok := spec.Type.(*ast.Ident); !ok || ident.Name != obj.Name() {
+					continue
+				}
+				if _, ok := directive("ignore", gen.Doc, spec.Doc, spec.Comment); ok {
+					continue
+				}
+
+				for i, name := range spec.Names {
+					if i >= len(spec.Values) {
+						break
+					}
+					lit, ok := spec.Values[i].(*ast.BasicLit)
+					if !ok {
+						continue
+					}
+
+					if j, ok := declared[name.Name]; ok {
+						if e := &collection.Enums[j]; e.Constraints != "" {
+							e.Constraints = orConstraints(e.Constraints, constraints)
+						}
+						continue
+					}
+
+					e := Enum{
+						Name:        name.Name,
+						Value:       lit.Value,
+						Type:        obj.Type().String(),
+						Package:     p.PkgPath,
+						Constraints: constraints,
+						Pos:         p.Fset.Position(name.Pos()),
+						Doc:         docText(gen, spec),
+						Labels:      labels(gen, spec),
+					}
+					if !c.keep(e, nil) {
+						c.logger.Debug("filtered declaration", "name", e.Name, "pos", e.Pos.String())
+						continue
+					}
+
+					if collection.Type == "" {
+						collection.Type = obj.Type().String()
+					}
+					declared[name.Name] = len(collection.Enums)
+					collection.Enums = append(collection.Enums, e)
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// fileConstraints returns the build constraints of f, from both its
+// //go:build line and its file name, such as windows for shell_windows.go.
+func fileConstraints(f *ast.File, file string) string {
+	var exprs []string
+	for _, g := range f.Comments {
+		if g.Pos() > f.Package {
+			break
+		}
+		for _, c := range g.List {
+			if !constraint.IsGoBuild(c.Text) {
+				continue
+			}
+			if expr, err := constraint.Parse(c.Text); err == nil {
+				exprs = append(exprs, expr.String())
+			}
+		}
+	}
+
+	parts := strings.Split(strings.TrimSuffix(filepath.Base(file), ".go"), "_")
+	if n := len(parts); n >= 3 && slices.Contains(knownOS, parts[n-2]) && slices.Contains(knownArch, parts[n-1]) {
+		exprs = append(exprs, parts[n-2]+" && "+parts[n-1])
+	} else if n >= 2 && (slices.Contains(knownOS, parts[n-1]) || slices.Contains(knownArch, parts[n-1])) {
+		exprs = append(exprs, parts[n-1])
+	}
+
+	if importsC(f) {
+		exprs = append(exprs, "cgo")
+	}
+
+	for i, expr := range exprs {
+		if len(exprs) > 1 {
+			exprs[i] = parenthesize(expr)
+		}
+	}
+
+	return strings.Join(exprs, " && ")
+}
+
+// importsC returns whether f uses cgo, which makes it only part of the
+// build when cgo is enabled.
+func importsC(f *ast.File) bool {
+	for _, imp := range f.Imports {
+		if imp.Path.Value == `"C"` {
+			return true
+		}
+	}
+
+	return false
+}
+
+// orConstraints returns the constraint satisfied by either a or b.
+func orConstraints(a, b string) string {
+	return parenthesize(a) + " || " + parenthesize(b)
+}
+
+// parenthesize wraps a constraint combining several terms in parentheses.
+func parenthesize(expr string) string {
+	if strings.ContainsAny(expr, " ") {
+		return "(" + expr + ")"
+	}
+
+	return expr
+}
@@ -0,0 +1,215 @@
+package enums
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Site is a place in the code handling the enums of a type.
+type Site struct {
+	Kind    string         // switch, map, or slice
+	Name    string         // the function or package level variable the site is in, e.g. AllFlags or (Flag).String
+	Package string         // the import path of the package the site is in
+	Pos     token.Position // where the site starts
+	Enums   []string       // the names of the enums the site mentions, in the order they're mentioned
+}
+
+// String outputs the site as it's shown in a coverage matrix.
+func (s Site) String() string {
+	return fmt.Sprintf("%s %s", s.Name, s.Kind)
+}
+
+// Handles returns whether the site mentions the enum called name.
+func (s Site) Handles(name string) bool {
+	for _, e := range s.Enums {
+		if e == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CoverageMatrix maps the enums of a collection to every site handling them.
+type CoverageMatrix struct {
+	Collection Collection
+	Sites      []Site // ordered by position
+}
+
+// String outputs the matrix as a Markdown table with a row per enum and a
+// column per site, so the sites a new value still needs to be added to
+// stand out.
+func (m CoverageMatrix) String() string {
+	var b strings.Builder
+	b.WriteString("| |")
+	for _, s := range m.Sites {
+		fmt.Fprintf(&b, " %s |", s)
+	}
+	b.WriteString("\n| --- |" + strings.Repeat(" --- |", len(m.Sites)) + "\n")
+
+	for _, e := range m.Collection.Enums {
+		fmt.Fprintf(&b, "| %s |", e.Name)
+		for _, s := range m.Sites {
+			if s.Handles(e.Name) {
+				b.WriteString(" x |")
+			} else {
+				b.WriteString("   |")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// Coverage finds every switch on, map literal keyed by, and slice literal of
+// typ in the packages matching pkg that mention its enums, to see at a glance
+// which handlers a new value still needs to be added to.
+//
+// Example:
+//
+//	matrix, _ := Coverage("./...", "feature.Flag")
+//	fmt.Println(matrix)
+func Coverage(pkg, typ string, opts ...Option) (CoverageMatrix, error) {
+	c := newConfig(opts)
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, err := load(ctx, c, pkg)
+	if err != nil {
+		return CoverageMatrix{}, err
+	}
+
+	collection, err := collect(ctx, c, pkgs, typ)
+	if err != nil {
+		return CoverageMatrix{}, err
+	}
+
+	matrix := CoverageMatrix{Collection: collection}
+	declared := make(map[string]bool, len(collection.Enums))
+	for _, e := range collection.Enums {
+		declared[e.qualifiedName()] = true
+	}
+
+	for _, p := range pkgs {
+		for _, f := range p.Syntax {
+			for _, d := range f.Decls {
+				switch d := d.(type) {
+				case *ast.FuncDecl:
+					if d.Body != nil {
+						matrix.Sites = append(matrix.Sites, sites(p, typ, declared, funcName(d), d.Body)...)
+					}
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						if spec, ok := spec.(*ast.ValueSpec); ok {
+							matrix.Sites = append(matrix.Sites, sites(p, typ, declared, spec.Names[0].Name, spec)...)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(matrix.Sites, func(i, j int) bool {
+		a, b := matrix.Sites[i].Pos, matrix.Sites[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		return a.Offset < b.Offset
+	})
+
+	return matrix, nil
+}
+
+// funcName returns the name of fn, qualified with its receiver for methods.
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+
+	return fmt.Sprintf("(%s).%s", types.ExprString(fn.Recv.List[0].Type), fn.Name.Name)
+}
+
+// sites returns the sites of typ in node mentioning the declared enums,
+// keyed by their qualifiedName.
+func sites(p *packages.Package, typ string, declared map[string]bool, name string, node ast.Node) []Site {
+	var found []Site
+	ast.Inspect(node, func(n ast.Node) bool {
+		var site Site
+		switch n := n.(type) {
+		case *ast.SwitchStmt:
+			if n.Tag == nil || p.TypesInfo.TypeOf(n.Tag) == nil || !matchesType(p.TypesInfo.TypeOf(n.Tag), typ) {
+				return true
+			}
+			site.Kind = "switch"
+		case *ast.CompositeLit:
+			lit := p.TypesInfo.TypeOf(n)
+			if lit == nil {
+				return true
+			}
+
+			switch t := lit.Underlying().(type) {
+			case *types.Slice:
+				if !matchesType(t.Elem(), typ) {
+					return true
+				}
+				site.Kind = "slice"
+			case *types.Array:
+				if !matchesType(t.Elem(), typ) {
+					return true
+				}
+				site.Kind = "slice"
+			case *types.Map:
+				if !matchesType(t.Key(), typ) {
+					return true
+				}
+				site.Kind = "map"
+			default:
+				return true
+			}
+		default:
+			return true
+		}
+
+		site.Name, site.Package, site.Pos = name, p.PkgPath, p.Fset.Position(n.Pos())
+		site.Enums = mentioned(p, declared, n)
+		if len(site.Enums) > 0 {
+			found = append(found, site)
+		}
+
+		// Nested sites, like a slice in a case of a switch, are sites of their own
+		return true
+	})
+
+	return found
+}
+
+// mentioned returns the names of the declared enums, keyed by their
+// qualifiedName, used in node in the order they're first used.
+func mentioned(p *packages.Package, declared map[string]bool, node ast.Node) []string {
+	var names []string
+	seen := make(map[string]bool)
+	ast.Inspect(node, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		obj := p.TypesInfo.Uses[ident]
+		if obj == nil || obj.Pkg() == nil || !declared[objectName(obj)] || seen[obj.Name()] {
+			return true
+		}
+
+		seen[obj.Name()] = true
+		names = append(names, obj.Name())
+		return true
+	})
+
+	return names
+}
@@ -0,0 +1,49 @@
+package enums_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestCoverage(t *testing.T) {
+	matrix, err := enums.Coverage("./testdata/coverage", "coverage.Flag")
+	require.NoError(t, err)
+
+	t.Run("finds the switches, maps, and slices mentioning the enums", func(t *testing.T) {
+		var sites []string
+		for _, s := range matrix.Sites {
+			sites = append(sites, s.String())
+		}
+
+		require.Equal(t, []string{"AllFlags slice", "labels map", "handle switch"}, sites)
+		require.Equal(t, []string{"FlagOn", "FlagOff"}, matrix.Sites[1].Enums)
+		require.Equal(t, 17, matrix.Sites[1].Pos.Line)
+	})
+
+	t.Run("outputs a table of the enums and the sites", func(t *testing.T) {
+		require.Equal(
+			t,
+			"| | AllFlags slice | labels map | handle switch |\n"+
+				"| --- | --- | --- | --- |\n"+
+				"| FlagBroken | x |   |   |\n"+
+				"| FlagOff | x | x |   |\n"+
+				"| FlagOn | x | x | x |\n",
+			matrix.String(),
+		)
+	})
+
+	t.Run("finds the sites of enums declared in another package than their type", func(t *testing.T) {
+		matrix, err := enums.Coverage("./testdata/crosspkg/...", "a.Flag")
+		require.NoError(t, err)
+
+		var sites []string
+		for _, s := range matrix.Sites {
+			sites = append(sites, s.String()+": "+strings.Join(s.Enums, ", "))
+		}
+		require.Equal(t, []string{"handle switch: FlagX, FlagY", "next map: FlagX, FlagY, FlagZ"}, sites)
+	})
+}
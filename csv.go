@@ -0,0 +1,53 @@
+package enums
+
+import (
+	"encoding/csv"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// WriteCSV writes the enums as CSV with a header and the columns name, value,
+// file, line, and doc. The file is relative to the module when the enum is
+// declared in it.
+//
+// Example:
+//
+//	flags, _ := All("./feature", "feature.Flag")
+//	flags.WriteCSV(os.Stdout)
+func (c Collection) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"name", "value", "file", "line", "doc"}); err != nil {
+		return err
+	}
+
+	for _, e := range c.Enums {
+		line := ""
+		if e.Pos.Line > 0 {
+			line = strconv.Itoa(e.Pos.Line)
+		}
+
+		if err := cw.Write([]string{e.Name, e.Value, c.relativeFile(e.Pos.Filename), line, e.Doc}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// relativeFile returns file relative to the module of the collection, or
+// file as is if it isn't inside of the module.
+func (c Collection) relativeFile(file string) string {
+	if c.Module.Dir == "" || file == "" {
+		return file
+	}
+
+	rel, err := filepath.Rel(c.Module.Dir, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return file
+	}
+
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,36 @@
+package enums_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestCollection_WriteCSV(t *testing.T) {
+	t.Run("has a row per enum with where it's declared and its doc", func(t *testing.T) {
+		collection, err := enums.All("./testdata/multimatch", "multimatch.Flag")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, collection.WriteCSV(&buf))
+
+		require.Equal(
+			t,
+			"name,value,file,line,doc\n"+
+				"FlagSomethingCouldBe,\"\"\"flag-whatever\"\"\",testdata/multimatch/example.go,8,\"FlagSomethingCouldBe is documented\nover two lines.\"\n"+
+				"FlagSomethingElse,\"\"\"flag-whomever\"\"\",testdata/multimatch/example.go,9,with a line comment\n",
+			buf.String(),
+		)
+	})
+
+	t.Run("only has the header without enums", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.Collection{}.WriteCSV(&buf))
+
+		require.Equal(t, "name,value,file,line,doc\n", buf.String())
+	})
+}
@@ -0,0 +1,136 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WriteMermaid writes a Mermaid flowchart of the enum types in matrices with
+// their values, and the switches, maps, and slices handling the values grouped
+// by package, for architecture documentation of event or state machine heavy
+// code. A site handling several of the types is drawn once.
+//
+// Example:
+//
+//	flags, _ := Coverage("./...", "feature.Flag")
+//	plans, _ := Coverage("./...", "billing.Plan")
+//	WriteMermaid(os.Stdout, flags, plans)
+func WriteMermaid(w io.Writer, matrices ...CoverageMatrix) error {
+	d := newDiagram(matrices)
+	label := func(s string) string { return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"` }
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, g := range d.groups {
+		fmt.Fprintf(&b, "    subgraph %s [%s]\n", g.id, label(g.label))
+		for _, n := range g.nodes {
+			fmt.Fprintf(&b, "        %s[%s]\n", n.id, label(n.label))
+		}
+		b.WriteString("    end\n")
+	}
+	for _, e := range d.edges {
+		fmt.Fprintf(&b, "    %s --> %s\n", e[0], e[1])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteDOT writes the same graph as WriteMermaid in the Graphviz DOT language.
+//
+// Example:
+//
+//	flags, _ := Coverage("./...", "feature.Flag")
+//	WriteDOT(os.Stdout, flags)
+func WriteDOT(w io.Writer, matrices ...CoverageMatrix) error {
+	d := newDiagram(matrices)
+	label := func(s string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph enums {\n\trankdir=LR;\n")
+	for _, g := range d.groups {
+		fmt.Fprintf(&b, "\tsubgraph cluster_%s {\n\t\tlabel=%s;\n", g.id, label(g.label))
+		for _, n := range g.nodes {
+			fmt.Fprintf(&b, "\t\t%s [label=%s, shape=%s];\n", n.id, label(n.label), n.shape)
+		}
+		b.WriteString("\t}\n")
+	}
+	for _, e := range d.edges {
+		fmt.Fprintf(&b, "\t%s -> %s;\n", e[0], e[1])
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// diagram is the graph written by WriteMermaid and WriteDOT, with a group per
+// type holding its values followed by a group per package holding its sites.
+type diagram struct {
+	groups []diagramGroup
+	edges  [][2]string // from site to value
+}
+
+type diagramGroup struct {
+	id, label string
+	nodes     []diagramNode
+}
+
+type diagramNode struct {
+	id, label, shape string
+}
+
+func newDiagram(matrices []CoverageMatrix) diagram {
+	var d diagram
+	pkgs := make(map[string]*diagramGroup)
+	sites := make(map[string]string) // position to node id, to draw shared sites once
+	for i, m := range matrices {
+		if m.Collection.Type == "" {
+			continue // nothing was found so there's nothing to draw
+		}
+
+		typ := diagramGroup{id: fmt.Sprintf("t%d", i), label: m.Collection.Type}
+		values := make(map[string]string, len(m.Collection.Enums))
+		for j, e := range m.Collection.Enums {
+			id := fmt.Sprintf("t%dv%d", i, j)
+			values[e.Name] = id
+			typ.nodes = append(typ.nodes, diagramNode{id: id, label: e.Name + " = " + e.Value, shape: "ellipse"})
+		}
+		d.groups = append(d.groups, typ)
+
+		for _, s := range m.Sites {
+			id, ok := sites[s.Pos.String()]
+			if !ok {
+				id = fmt.Sprintf("s%d", len(sites))
+				sites[s.Pos.String()] = id
+
+				pkg, ok := pkgs[s.Package]
+				if !ok {
+					pkg = &diagramGroup{label: s.Package}
+					pkgs[s.Package] = pkg
+				}
+				pkg.nodes = append(pkg.nodes, diagramNode{id: id, label: s.String(), shape: "box"})
+			}
+
+			for _, name := range s.Enums {
+				d.edges = append(d.edges, [2]string{id, values[name]})
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(pkgs))
+	for path := range pkgs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for i, path := range paths {
+		pkgs[path].id = fmt.Sprintf("p%d", i)
+		d.groups = append(d.groups, *pkgs[path])
+	}
+
+	return d
+}
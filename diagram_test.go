@@ -0,0 +1,86 @@
+package enums_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestWriteMermaid(t *testing.T) {
+	matrix, err := enums.Coverage("./testdata/coverage", "coverage.Flag")
+	require.NoError(t, err)
+
+	t.Run("draws the values of the type and the sites handling them", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, enums.WriteMermaid(&b, matrix))
+
+		require.Equal(
+			t,
+			`flowchart LR
+    subgraph t0 ["github.com/gaqzi/enums/testdata/coverage.Flag"]
+        t0v0["FlagBroken = #quot;broken#quot;"]
+        t0v1["FlagOff = #quot;off#quot;"]
+        t0v2["FlagOn = #quot;on#quot;"]
+    end
+    subgraph p0 ["github.com/gaqzi/enums/testdata/coverage"]
+        s0["AllFlags slice"]
+        s1["labels map"]
+        s2["handle switch"]
+    end
+    s0 --> t0v2
+    s0 --> t0v1
+    s0 --> t0v0
+    s1 --> t0v2
+    s1 --> t0v1
+    s2 --> t0v2
+`,
+			b.String(),
+		)
+	})
+
+	t.Run("draws a site handling several types once", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, enums.WriteMermaid(&b, matrix, matrix))
+
+		require.Equal(t, 1, strings.Count(b.String(), `s2["handle switch"]`))
+		require.Contains(t, b.String(), "s2 --> t1v2\n")
+	})
+}
+
+func TestWriteDOT(t *testing.T) {
+	matrix, err := enums.Coverage("./testdata/coverage", "coverage.Flag")
+	require.NoError(t, err)
+
+	var b strings.Builder
+	require.NoError(t, enums.WriteDOT(&b, matrix))
+
+	require.Equal(
+		t,
+		`digraph enums {
+	rankdir=LR;
+	subgraph cluster_t0 {
+		label="github.com/gaqzi/enums/testdata/coverage.Flag";
+		t0v0 [label="FlagBroken = \"broken\"", shape=ellipse];
+		t0v1 [label="FlagOff = \"off\"", shape=ellipse];
+		t0v2 [label="FlagOn = \"on\"", shape=ellipse];
+	}
+	subgraph cluster_p0 {
+		label="github.com/gaqzi/enums/testdata/coverage";
+		s0 [label="AllFlags slice", shape=box];
+		s1 [label="labels map", shape=box];
+		s2 [label="handle switch", shape=box];
+	}
+	s0 -> t0v2;
+	s0 -> t0v1;
+	s0 -> t0v0;
+	s1 -> t0v2;
+	s1 -> t0v1;
+	s2 -> t0v2;
+}
+`,
+		b.String(),
+	)
+}
@@ -0,0 +1,55 @@
+package enums
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// directivePrefix starts every comment directive enums understands, written
+// like other Go directives without a space after the slashes.
+const directivePrefix = "//enums:"
+
+// directive returns the arguments of the first //enums:<name> directive in
+// groups, and whether it was found at all. The arguments are separated from
+// the name by either a space or an equals sign.
+//
+// Example:
+//
+//	//enums:ignore only used in tests
+//	//enums:group=payments
+//
+// Have the arguments "only used in tests" and "payments".
+func directive(name string, groups ...*ast.CommentGroup) (args string, ok bool) {
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+
+		for _, c := range g.List {
+			rest, found := strings.CutPrefix(c.Text, directivePrefix+name)
+			if !found || (rest != "" && rest[0] != ' ' && rest[0] != '\t' && rest[0] != '=') {
+				continue
+			}
+
+			return strings.TrimSpace(strings.TrimPrefix(rest, "=")), true
+		}
+	}
+
+	return "", false
+}
+
+// labels returns the labels set by directives on spec, where a directive on
+// the spec itself takes precedence over one on the declaration it's part of.
+func labels(gen *ast.GenDecl, spec *ast.ValueSpec) map[string]string {
+	var l map[string]string
+	for _, name := range []string{"group", "alias-of", "owner"} {
+		if args, ok := directive(name, spec.Doc, spec.Comment, gen.Doc); ok {
+			if l == nil {
+				l = make(map[string]string)
+			}
+			l[name] = args
+		}
+	}
+
+	return l
+}
@@ -0,0 +1,178 @@
+package enums
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TypeInfo describes a type found by DiscoverTypes or DiscoverStructTypes.
+type TypeInfo struct {
+	Type       string         // the import path of the type, can be passed to All as is
+	Underlying string         // the name of the underlying basic type, e.g. string or int, or struct
+	FieldName  string         // the field tagged with `enums:"identifier"` for struct types
+	Values     int            // the number of package level constants, or variables for struct types, of the type
+	Pos        token.Position // where the type is declared
+}
+
+// DiscoverTypes lists the named string and integer types in the packages
+// matching pattern that have at least minValues package level constants, to
+// find types that are used as enums but aren't checked yet.
+//
+// Example:
+//
+//	DiscoverTypes("./...", 3)
+func DiscoverTypes(pattern string, minValues int, opts ...Option) ([]TypeInfo, error) {
+	return discover(pattern, opts, func(p *packages.Package) []TypeInfo {
+		scope := p.Types.Scope()
+		values := make(map[*types.TypeName]int)
+		for _, name := range scope.Names() {
+			k, ok := scope.Lookup(name).(*types.Const)
+			if !ok {
+				continue
+			}
+
+			// Only types declared in the same package, constants of a type from another package are a different use
+			if named, ok := k.Type().(*types.Named); ok && named.Obj().Pkg() == p.Types {
+				values[named.Obj()]++
+			}
+		}
+
+		var found []TypeInfo
+		for obj, n := range values {
+			basic, ok := obj.Type().Underlying().(*types.Basic)
+			if !ok || basic.Info()&(types.IsString|types.IsInteger) == 0 || n < minValues {
+				continue
+			}
+
+			found = append(found, TypeInfo{
+				Type:       obj.Type().String(),
+				Underlying: basic.Name(),
+				Values:     n,
+				Pos:        p.Fset.Position(obj.Pos()),
+			})
+		}
+
+		return found
+	})
+}
+
+// DiscoverStructTypes lists the struct types in the packages matching
+// pattern with a field tagged `enums:"identifier"`, either directly or
+// promoted from an embedded struct, so every struct enum in a module can be
+// checked in a single loop.
+//
+// Example:
+//
+//	types, _ := DiscoverStructTypes("./...")
+//	for _, info := range types {
+//		enumstest.NoDiff(t, "./...", info.Type, handled[info.Type])
+//	}
+func DiscoverStructTypes(pattern string, opts ...Option) ([]TypeInfo, error) {
+	return discover(pattern, opts, func(p *packages.Package) []TypeInfo {
+		scope := p.Types.Scope()
+
+		var found []TypeInfo
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() {
+				continue
+			}
+
+			struc, ok := obj.Type().Underlying().(*types.Struct)
+			if !ok {
+				continue
+			}
+
+			fieldName := identifierField(struc)
+			if fieldName == "" {
+				continue
+			}
+
+			var values int
+			for _, name := range scope.Names() {
+				if v, ok := scope.Lookup(name).(*types.Var); ok && types.Identical(v.Type(), obj.Type()) {
+					values++
+				}
+			}
+
+			found = append(found, TypeInfo{
+				Type:       obj.Type().String(),
+				Underlying: "struct",
+				FieldName:  fieldName,
+				Values:     values,
+				Pos:        p.Fset.Position(obj.Pos()),
+			})
+		}
+
+		return found
+	})
+}
+
+// identifierField returns the name of the field of struc tagged
+// `enums:"identifier"`, looking into embedded structs for a promoted field.
+func identifierField(struc *types.Struct) string {
+	for i := 0; i < struc.NumFields(); i++ {
+		if reflect.StructTag(struc.Tag(i)).Get("enums") == "identifier" {
+			return struc.Field(i).Name()
+		}
+	}
+
+	for i := 0; i < struc.NumFields(); i++ {
+		f := struc.Field(i)
+		if !f.Embedded() {
+			continue
+		}
+
+		typ := f.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		if inner, ok := typ.Underlying().(*types.Struct); ok {
+			if name := identifierField(inner); name != "" {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+// discover loads the packages matching pattern and returns what find finds
+// in each of them, sorted by type.
+func discover(pattern string, opts []Option, find func(p *packages.Package) []TypeInfo) ([]TypeInfo, error) {
+	c := newConfig(opts)
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, err := load(ctx, c, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []TypeInfo
+	for _, p := range pkgs {
+		if p.Types == nil {
+			continue
+		}
+
+		found = append(found, find(p)...)
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].Type < found[j].Type })
+
+	return found, nil
+}
+
+// String outputs the type info in the file:line:col: message format editors understand.
+func (t TypeInfo) String() string {
+	if t.FieldName != "" {
+		return fmt.Sprintf("%s: %s has %d values identified by %s", t.Pos, t.Type, t.Values, t.FieldName)
+	}
+
+	return fmt.Sprintf("%s: %s has %d %s constants", t.Pos, t.Type, t.Values, t.Underlying)
+}
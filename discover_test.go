@@ -0,0 +1,78 @@
+package enums_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestDiscoverTypes(t *testing.T) {
+	t.Run("finds types with at least the number of constants", func(t *testing.T) {
+		found, err := enums.DiscoverTypes("./testdata/diagnostics", 2)
+		require.NoError(t, err)
+
+		file, err := filepath.Abs("testdata/diagnostics/example.go")
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.Equal(t, "github.com/gaqzi/enums/testdata/diagnostics.Stage", found[0].Type)
+		require.Equal(t, "int", found[0].Underlying)
+		require.Equal(t, 2, found[0].Values)
+		require.Equal(t, file, found[0].Pos.Filename)
+	})
+
+	t.Run("lists string and integer types sorted by type", func(t *testing.T) {
+		found, err := enums.DiscoverTypes("./testdata/diagnostics", 1)
+		require.NoError(t, err)
+
+		var types []string
+		for _, info := range found {
+			types = append(types, info.Type+" "+info.Underlying)
+		}
+		require.Equal(
+			t,
+			[]string{
+				"github.com/gaqzi/enums/testdata/diagnostics.Flag string",
+				"github.com/gaqzi/enums/testdata/diagnostics.Stage int",
+			},
+			types,
+		)
+	})
+
+	t.Run("finds types from export data", func(t *testing.T) {
+		found, err := enums.DiscoverTypes("./testdata/diagnostics", 2, enums.WithExportData())
+		require.NoError(t, err)
+
+		require.Len(t, found, 1)
+		require.Equal(t, "github.com/gaqzi/enums/testdata/diagnostics.Stage", found[0].Type)
+	})
+}
+
+func TestDiscoverStructTypes(t *testing.T) {
+	found, err := enums.DiscoverStructTypes("./testdata/full")
+	require.NoError(t, err)
+
+	var types []string
+	for _, info := range found {
+		types = append(types, fmt.Sprintf("%s %s %d", info.Type, info.FieldName, info.Values))
+	}
+	require.Equal(
+		t,
+		[]string{
+			"github.com/gaqzi/enums/testdata/full.Base Name 0",
+			"github.com/gaqzi/enums/testdata/full.EmbeddedFlag Name 2",
+			"github.com/gaqzi/enums/testdata/full.FlagStruct Name 1",
+		},
+		types,
+		"expected tagged structs and structs embedding them",
+	)
+
+	for _, info := range found[1:] {
+		collection, err := enums.All("./testdata/full", info.Type)
+		require.NoError(t, err)
+		require.Len(t, collection.Enums, info.Values, "expected the discovered type to be usable with All")
+	}
+}
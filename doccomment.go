@@ -0,0 +1,95 @@
+package enums
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// docValuesHeading starts the list of values in a doc comment, the list
+// runs to the end of the doc comment.
+const docValuesHeading = "// Values:"
+
+var (
+	docValueLine = regexp.MustCompile(`^//   - \S+ = .+$`)
+	docDirective = regexp.MustCompile(`^//[a-z0-9]+:[a-z0-9]`)
+)
+
+// PatchDocComment returns the Go source file src with a list of every enum
+// in the collection at the end of the doc comment of the function fn, such
+// as AllFlags, so its documentation stays accurate when run by go generate:
+//
+//	// AllFlags returns every flag.
+//	//
+//	// Values:
+//	//   - DeployAllTheThings = "deploy-all-the-things"
+//	//   - DeployOneThing = "deploy-one-thing"
+//	func AllFlags() []Flag {
+//
+// An existing list is replaced. If the list has been edited by hand with
+// anything but values an error is returned instead of throwing the edit away.
+func PatchDocComment(src []byte, fn string, c Collection) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source: %w", err)
+	}
+
+	block := docValuesHeading + "\n"
+	for _, e := range c.Enums {
+		block += fmt.Sprintf("//   - %s = %s\n", e.Name, e.Value)
+	}
+
+	for _, d := range f.Decls {
+		decl, ok := d.(*ast.FuncDecl)
+		if !ok || decl.Recv != nil || decl.Name.Name != fn {
+			continue
+		}
+
+		offset := func(p token.Pos) int { return fset.Position(p).Offset }
+		if decl.Doc == nil {
+			start := offset(decl.Pos())
+			return format.Source(append(append(append([]byte(nil), src[:start]...), block...), src[start:]...))
+		}
+
+		// Directives are kept at the end of the doc comment, after the values
+		comments := decl.Doc.List
+		for len(comments) > 0 && docDirective.MatchString(comments[len(comments)-1].Text) {
+			comments = comments[:len(comments)-1]
+		}
+		if len(comments) == 0 {
+			start := offset(decl.Doc.Pos())
+			return format.Source(append(append(append([]byte(nil), src[:start]...), block...), src[start:]...))
+		}
+
+		heading := -1
+		for i, comment := range comments {
+			if comment.Text == docValuesHeading {
+				heading = i
+			}
+		}
+
+		var patched []byte
+		if heading < 0 {
+			end := offset(comments[len(comments)-1].End())
+			patched = append(append(append(patched, src[:end]...), "\n//\n"+strings.TrimSuffix(block, "\n")...), src[end:]...)
+		} else {
+			for _, comment := range comments[heading+1:] {
+				if comment.Text != "//" && !docValueLine.MatchString(comment.Text) {
+					return nil, fmt.Errorf("%s: the values in the doc comment of %s were edited by hand: %s", fset.Position(comment.Pos()), fn, comment.Text)
+				}
+			}
+
+			start, end := offset(comments[heading].Pos()), offset(comments[len(comments)-1].End())
+			patched = append(append(append(patched, src[:start]...), strings.TrimSuffix(block, "\n")...), src[end:]...)
+		}
+
+		return format.Source(patched)
+	}
+
+	return nil, fmt.Errorf("function %s not declared in the source", fn)
+}
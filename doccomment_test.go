@@ -0,0 +1,58 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestPatchDocComment(t *testing.T) {
+	collection := enums.Collection{
+		Type:  "example.com/feature.Flag",
+		Enums: []enums.Enum{{Name: "FlagA", Value: `"a"`}, {Name: "FlagB", Value: `"b"`}},
+	}
+	values := "// Values:\n//   - FlagA = \"a\"\n//   - FlagB = \"b\"\n"
+
+	t.Run("adds the values to the end of the doc comment", func(t *testing.T) {
+		src := "package feature\n\n// AllFlags returns every flag.\nfunc AllFlags() []Flag { return nil }\n"
+
+		patched, err := enums.PatchDocComment([]byte(src), "AllFlags", collection)
+		require.NoError(t, err)
+
+		require.Equal(t, "package feature\n\n// AllFlags returns every flag.\n//\n"+values+"func AllFlags() []Flag { return nil }\n", string(patched))
+	})
+
+	t.Run("replaces the values in the doc comment", func(t *testing.T) {
+		src := "package feature\n\n// AllFlags returns every flag.\n//\n// Values:\n//   - FlagA = \"a\"\n//\n//go:noinline\nfunc AllFlags() []Flag { return nil }\n"
+
+		patched, err := enums.PatchDocComment([]byte(src), "AllFlags", collection)
+		require.NoError(t, err)
+
+		require.Equal(t, "package feature\n\n// AllFlags returns every flag.\n//\n"+values+"//\n//go:noinline\nfunc AllFlags() []Flag { return nil }\n", string(patched))
+	})
+
+	t.Run("adds a doc comment to a function without one", func(t *testing.T) {
+		src := "package feature\n\nfunc AllFlags() []Flag { return nil }\n"
+
+		patched, err := enums.PatchDocComment([]byte(src), "AllFlags", collection)
+		require.NoError(t, err)
+
+		require.Equal(t, "package feature\n\n"+values+"func AllFlags() []Flag { return nil }\n", string(patched))
+	})
+
+	t.Run("fails when the values were edited by hand", func(t *testing.T) {
+		src := "package feature\n\n// Values:\n//   - FlagA = \"a\"\n// FlagB is on its way out.\nfunc AllFlags() []Flag { return nil }\n"
+
+		_, err := enums.PatchDocComment([]byte(src), "AllFlags", collection)
+
+		require.EqualError(t, err, "5:1: the values in the doc comment of AllFlags were edited by hand: // FlagB is on its way out.")
+	})
+
+	t.Run("fails when the function isn't in the source", func(t *testing.T) {
+		_, err := enums.PatchDocComment([]byte("package feature\n"), "AllFlags", collection)
+
+		require.EqualError(t, err, "function AllFlags not declared in the source")
+	})
+}
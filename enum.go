@@ -5,6 +5,8 @@
 // logic while still being warned when new cases pop up.
 //
 // Enums supports basic literals and structs tagged with `enum:"identifier"`.
+// Declarations with a //enums:ignore directive in their doc or line comment
+// are left out, such as sentinels only used in tests.
 //
 // The entry point for enums is the All function which takes a package path
 // and a type and will return a Collection of found instances of that type.
@@ -15,145 +17,1141 @@
 package enums
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
+	"iter"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"golang.org/x/sync/singleflight"
 	"golang.org/x/tools/go/packages"
 )
 
 // Collection contains found matches from All and can be diffed against values.
+//
+// The methods of a collection never change it, modifiers like With and
+// Filter return a copy, so a collection can be shared between goroutines.
 type Collection struct {
-	Type      string // the import path of the type
+	Type      string // the import path of the type, when the query matches several types Enum.Type has the type of each value
 	FieldName string // if the underlying type is a struct this value is the name of the field that is used to distinguish flags
+	Module    Module // the module the type is declared in, zero if it wasn't loaded from a module
+	Interface bool   // the type is an interface and the values are the names of the concrete types assigned to it
 	Enums     []Enum // all distinct values found
+
+	Diagnostics []Diagnostic // declarations of the type that were skipped and why
+	Ignored     []Diagnostic // declarations of the type left out by an //enums:ignore directive, with its arguments as the reason
+}
+
+// Diagnostic explains why a declaration of the type isn't part of a Collection.
+type Diagnostic struct {
+	Name   string         // the name of the declaration
+	Pos    token.Position // where the declaration is
+	Reason string         // why it was skipped
+}
+
+func newDiagnostic(p *packages.Package, ident *ast.Ident, reason string) Diagnostic {
+	return Diagnostic{Name: ident.Name, Pos: p.Fset.Position(ident.Pos()), Reason: reason}
+}
+
+// String outputs the diagnostic in the file:line:col: message format editors understand.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s skipped: %s", d.Pos, d.Name, d.Reason)
+}
+
+// ScanError is a declaration of the type whose value couldn't be extracted,
+// with where it is so it can be found among many declarations.
+type ScanError struct {
+	Name string         // the name of the declaration
+	Pos  token.Position // where the declaration is
+	Err  error          // why the value couldn't be extracted
+}
+
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("%s:%d: %s: %s", e.Pos.Filename, e.Pos.Line, e.Name, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// Err returns the diagnostics of the collection as *ScanError joined
+// together, or nil if no declarations were skipped. For callers that want
+// to fail on any declaration that couldn't be scanned.
+//
+// Example:
+//
+//	if err := collection.Err(); err != nil {
+//		var scanErr *ScanError
+//		errors.As(err, &scanErr) // the first declaration that was skipped
+//	}
+func (c Collection) Err() error {
+	var errs []error
+	for _, d := range c.Diagnostics {
+		errs = append(errs, &ScanError{Name: d.Name, Pos: d.Pos, Err: errors.New(d.Reason)})
+	}
+
+	return errors.Join(errs...)
+}
+
+// All returns an iterator over the enums in the collection, in the same order as Enums.
+//
+// Example:
+//
+//	for e := range collection.All() {
+//		fmt.Println(e.Name, e.Value)
+//	}
+func (c Collection) All() iter.Seq[Enum] {
+	return func(yield func(Enum) bool) {
+		for _, e := range c.Enums {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of enums in the collection.
+func (c Collection) Len() int {
+	return len(c.Enums)
+}
+
+// At returns a copy of the enum at index i, changing it doesn't change the
+// collection. It panics if i is out of range like indexing a slice.
+func (c Collection) At(i int) Enum {
+	return c.Enums[i].clone()
+}
+
+// With returns a copy of the collection with the enums added at the end,
+// the collection itself is never changed so it's safe to share between
+// goroutines, like parallel tests using the same collection.
+//
+// Example:
+//
+//	flags.With(enums.Enum{Name: "FlagLegacy", Value: `"legacy"`})
+func (c Collection) With(es ...Enum) Collection {
+	clone := c.clone()
+	for _, e := range es {
+		clone.Enums = append(clone.Enums, e.clone())
+	}
+
+	return clone
+}
+
+// Filter returns a copy of the collection with only the enums keep returns
+// true for, the collection itself is never changed.
+//
+// Example:
+//
+//	flags.Filter(func(e enums.Enum) bool { return e.Labels["group"] == "payments" })
+func (c Collection) Filter(keep func(Enum) bool) Collection {
+	clone := c
+	clone.Enums = nil
+	clone.Diagnostics = append([]Diagnostic(nil), c.Diagnostics...)
+	clone.Ignored = append([]Diagnostic(nil), c.Ignored...)
+	for _, e := range c.Enums {
+		if keep(e) {
+			clone.Enums = append(clone.Enums, e.clone())
+		}
+	}
+
+	return clone
+}
+
+// displayName returns the name of e, qualified with the name of its package
+// when another enum in the collection has the same name in another package.
+func (c Collection) displayName(e Enum) string {
+	for _, other := range c.Enums {
+		if other.Name == e.Name && other.Package != e.Package {
+			return path.Base(e.Package) + "." + e.Name
+		}
+	}
+
+	return e.Name
+}
+
+// clone returns a deep copy of the collection that shares no slices or maps with it.
+func (c Collection) clone() Collection {
+	clone := c
+	clone.Enums = nil
+	for _, e := range c.Enums {
+		clone.Enums = append(clone.Enums, e.clone())
+	}
+	clone.Diagnostics = append([]Diagnostic(nil), c.Diagnostics...)
+	clone.Ignored = append([]Diagnostic(nil), c.Ignored...)
+
+	return clone
+}
+
+// Values returns the values of the enums with string literals unquoted, to
+// diff one collection against another.
+//
+// Example:
+//
+//	flags.Diff(spec.Values())
+func (c Collection) Values() []string {
+	values := make([]string, len(c.Enums))
+	for i, e := range c.Enums {
+		values[i] = e.Unquoted()
+	}
+
+	return values
+}
+
+// GroupBy splits the collection into one collection per value of label, such
+// as "group" for the //enums:group directive. Enums without the label are in
+// the collection for the empty string.
+//
+// Example:
+//
+//	collection.GroupBy("group")["payments"].Diff(payments.Flags())
+func (c Collection) GroupBy(label string) map[string]Collection {
+	groups := make(map[string]Collection)
+	for _, e := range c.Enums {
+		group, ok := groups[e.Labels[label]]
+		if !ok {
+			group = Collection{Type: c.Type, FieldName: c.FieldName, Module: c.Module, Interface: c.Interface}
+		}
+		group.Enums = append(group.Enums, e.clone())
+		groups[e.Labels[label]] = group
+	}
+
+	return groups
+}
+
+// Module is the Go module the type of a Collection is declared in.
+type Module struct {
+	Path    string // the module path, e.g. github.com/gaqzi/enums
+	Version string // the module version, empty for the main module
+	Dir     string // the directory holding the module's files
+}
+
+// Enum represents a value for a matched type.
+//
+// Example:
+//
+//	var MyFlag Flag = "Hello"
+//
+// Is equivalent to:
+//
+//	Enum{Name: "MyFlag", Value: "Hello"}
+type Enum struct {
+	Name        string
+	Value       string
+	Type        string            // the import path of the type the enum is declared as, the same as Collection.Type unless the scan matched several types
+	Package     string            // the import path of the package the enum is declared in, to tell apart enums with the same name in different packages
+	Constraints string            // the build constraints of the file the enum is declared in when it's not part of the current build, such as windows, only set WithConstrained
+	Pos         token.Position    // where the enum is declared
+	Doc         string            // the doc comment of the declaration, or its line comment if it has no doc
+	Fields      map[string]string // the source of every field set in a struct literal, including the identifier, nil for other values
+	Labels      map[string]string // set by directives on the declaration, such as "group" from //enums:group=payments, "alias-of", and "owner"
+
+	Object types.Object   `json:"-"` // the declaration as type checked, only set WithObjects
+	Spec   *ast.ValueSpec `json:"-"` // the syntax of the declaration, only set WithObjects and not when reading export data
+}
+
+// clone returns a copy of the enum that shares no maps with it.
+func (e Enum) clone() Enum {
+	e.Fields = maps.Clone(e.Fields)
+	e.Labels = maps.Clone(e.Labels)
+
+	return e
+}
+
+// Unquoted returns the value of e as Values and Diff compare it, see
+// Unquote. Value has the literal as written.
+func (e Enum) Unquoted() string {
+	return Unquote(e.Value)
+}
+
+// Rune returns the rune of an enum declared with a rune literal, such as
+// const Tab Key = '\t', and whether it was. Value has the literal as written.
+func (e Enum) Rune() (rune, bool) {
+	return runeLit(e.Value)
+}
+
+// qualifiedName returns the import path of the package e is declared in
+// and its name, such as github.com/acme/feature.FlagOn, the same as
+// objectName returns for its declaration, which may be in another package
+// than its type.
+func (e Enum) qualifiedName() string {
+	return e.Package + "." + e.Name
+}
+
+// objectName returns the import path of the package obj is declared in and
+// its name.
+func objectName(obj types.Object) string {
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// Owner returns the team owning the enum from an //enums:owner directive,
+// such as team-payments, or an empty string if it has no owner.
+func (e Enum) Owner() string {
+	return e.Labels["owner"]
+}
+
+// AliasOf returns the name of the enum this is an alias of from an
+// //enums:alias-of directive, or an empty string if it isn't an alias.
+func (e Enum) AliasOf() string {
+	return e.Labels["alias-of"]
+}
+
+// All finds variables of typ in pkg.
+//
+// When some of the packages matching pkg fail to load the enums found in
+// the others are returned together with the errors, joined with
+// errors.Join, so the caller decides whether partial results will do.
+// Declarations that fail to extract don't fail the scan, they're skipped
+// and explained in Collection.Diagnostics, and Collection.Err joins them
+// for callers that want to treat them as errors.
+//
+// The type is either fully qualified with its import path, which only
+// matches that type, or shortened to any suffix of it, such as feature.Flag,
+// which matches every type ending with it. Use the fully qualified form when
+// pkg is a pattern like ./... and several packages declare a type of the
+// same name.
+//
+// Identical scans running at the same time, such as from parallel tests,
+// share a single load of the packages and the options of the scan that
+// started first.
+//
+// Example:
+//
+//	All("./feature", "feature.Flag")
+func All(pkg string, typ string, opts ...Option) (Collection, error) {
+	return all(newConfig(opts), pkg, typ)
+}
+
+// scans deduplicates identical scans running at the same time.
+var scans singleflight.Group
+
+func all(c config, pkg, typ string) (Collection, error) {
+	if len(c.filters) > 0 {
+		// Functions can't be compared so there's no telling whether two scans are identical
+		return scan(c, pkg, typ)
+	}
+
+	v, err, shared := scans.Do(c.key(pkg, typ), func() (interface{}, error) {
+		if _, n := registeredExtractors(); c.cache != nil && !c.objects && n == 0 {
+			return cachedScan(c, pkg, typ)
+		}
+
+		return scan(c, pkg, typ)
+	})
+
+	collection := v.(Collection)
+	if shared {
+		// Every caller gets their own copy so changing one result doesn't change the others
+		collection = collection.clone()
+	}
+
+	return collection, err
+}
+
+// key identifies a scan of typ in pkg with the options of c and the
+// registered extractors, except for the filters which can't be compared.
+func (c config) key(pkg, typ string) string {
+	gen, _ := registeredExtractors()
+	return strings.Join([]string{strconv.FormatUint(gen, 10), c.dir, strings.Join(c.env, "\n"), strings.Join(c.flags, " "), strconv.FormatBool(c.exportData), strconv.FormatBool(c.objects), strconv.FormatBool(c.constrained), strconv.FormatBool(c.noCgo), fmt.Sprint(c.registrations), pkg, typ}, "\x00")
+}
+
+func scan(c config, pkg, typ string) (Collection, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, loadErr := load(ctx, c, pkg)
+	if len(pkgs) == 0 && loadErr != nil {
+		return Collection{}, loadErr
+	}
+
+	collection, err := collect(ctx, c, pkgs, typ)
+	var notFound *TypeNotFoundError
+	if loadErr != nil && errors.As(err, &notFound) {
+		return collection, loadErr // the type may well be declared in a package that failed to load
+	}
+
+	return collection, errors.Join(loadErr, err)
+}
+
+// LoadMode is the packages.LoadMode packages passed to FromPackages need to have been loaded with at least.
+//
+// Only the matched packages are type checked from source, their dependencies
+// are read from export data as only the declarations in the matched packages
+// are scanned.
+const LoadMode = packages.NeedName | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedModule
+
+// FromPackages finds variables of typ in packages that have already been
+// loaded, so tools that load the program themselves don't pay for a second
+// load. The packages must have been loaded with at least LoadMode.
+//
+// Example:
+//
+//	pkgs, _ := packages.Load(&packages.Config{Mode: enums.LoadMode}, "./feature")
+//	FromPackages(pkgs, "feature.Flag")
+func FromPackages(pkgs []*packages.Package, typ string, opts ...Option) (Collection, error) {
+	c := newConfig(opts)
+	for _, p := range pkgs {
+		if c.exportData && p.Types == nil {
+			return Collection{}, fmt.Errorf("package %s is missing type information, load it with enums.ExportDataLoadMode", p.PkgPath)
+		}
+
+		if !c.exportData && (p.TypesInfo == nil || p.Syntax == nil) {
+			return Collection{}, fmt.Errorf("package %s is missing syntax or type information, load it with enums.LoadMode", p.PkgPath)
+		}
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	return collect(ctx, c, pkgs, typ)
+}
+
+// load loads the packages matching pkg. Packages with type errors are still
+// returned, as the declarations that type check can be collected anyway.
+//
+// An absolute pkg, such as from CallerRelative, is loaded from its own
+// directory unless a directory is set, so it resolves in its own module.
+func load(ctx context.Context, c config, pkg string) ([]*packages.Package, error) {
+	start := time.Now()
+	cfg := c.packagesConfig(ctx, pkg, LoadMode)
+	if c.exportData {
+		cfg.Mode = ExportDataLoadMode
+	}
+	if c.constrained || c.noCgo {
+		cfg.Mode |= packages.NeedFiles
+	}
+	pkgs, err := packages.Load(&cfg, pkg)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("loading package timed out after %s: %w", c.timeout, ctxErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package: %w", err)
+	}
+	if err := loadErrors(pkgs); err != nil {
+		return pkgs, fmt.Errorf("failed to load package: %w", err)
+	}
+	c.logger.Debug("loaded packages", "pattern", pkg, "dir", cfg.Dir, "packages", len(pkgs), "duration", time.Since(start))
+
+	return pkgs, nil
+}
+
+// packagesConfig returns how to load pkg with mode, from the directory of
+// pkg when it's an absolute path and no directory is set.
+func (c config) packagesConfig(ctx context.Context, pkg string, mode packages.LoadMode) packages.Config {
+	dir := c.dir
+	if dir == "" && filepath.IsAbs(pkg) {
+		dir = strings.TrimSuffix(pkg, "/...")
+	}
+	env := c.env
+	if c.noCgo {
+		if env == nil {
+			env = os.Environ()
+		}
+		env = append(slices.Clip(env), "CGO_ENABLED=0")
+	}
+
+	return packages.Config{Context: ctx, Mode: mode, Dir: dir, Env: workspaceEnv(ctx, dir, env), BuildFlags: c.flags}
+}
+
+// loadErrors returns the errors of the packages that mean they couldn't be
+// loaded, such as a directory that doesn't exist or an import that doesn't
+// resolve, each prefixed with the package it's from. Other type errors are
+// tolerated as the rest of the package can still be scanned.
+func loadErrors(pkgs []*packages.Package) error {
+	var errs []error
+	for _, p := range pkgs {
+		for _, err := range p.Errors {
+			switch {
+			case err.Kind == packages.TypeError && strings.HasPrefix(err.Msg, "could not import "):
+			case err.Kind == packages.ListError && len(p.TypeErrors) == 0:
+				// A package that type checked with errors also has them as the output of the compiler
+			default:
+				continue
+			}
+
+			errs = append(errs, fmt.Errorf("%s: %w", p.PkgPath, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collect finds variables of typ in already loaded packages.
+//
+// If ctx is done before all packages have been scanned the values found so
+// far are returned together with the error.
+func collect(ctx context.Context, c config, pkgs []*packages.Package, typ string) (Collection, error) {
+	collections, errs := collectEach(ctx, c, pkgs, []string{typ})
+	return collections[0], errs[0]
+}
+
+// collectEach is collect for each of typs in a single pass over the
+// packages, so the progress is only reported once. The collections and
+// errors are in the order of typs.
+func collectEach(ctx context.Context, c config, pkgs []*packages.Package, typs []string) ([]Collection, []error) {
+	var err error
+	collections := make([]Collection, len(typs))
+	typeFound := make([]bool, len(typs))
+	c.reportProgress(0, len(pkgs), "")
+	for i, p := range pkgs {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = fmt.Errorf("scan timed out after %s, returning the values found so far: %w", c.timeout, ctxErr)
+			break
+		}
+
+		for _, err := range p.TypeErrors {
+			c.logger.Debug("type error", "package", p.PkgPath, "error", err.Error())
+		}
+		if c.exportData && p.Types == nil {
+			continue // it failed to load
+		}
+		for j, typ := range typs {
+			c.logger.Debug("scanning package", "package", p.PkgPath, "type", typ)
+			collection := &collections[j]
+			if c.exportData {
+				n := len(collection.Enums)
+				if collectExportData(collection, p, typ) {
+					typeFound[j] = true
+				}
+				c.added(collection, n, p, nil)
+			} else if collectSyntax(c, collection, p, typ) {
+				typeFound[j] = true
+			}
+		}
+		c.reportProgress(i+1, len(pkgs), p.PkgPath)
+	}
+
+	errs := make([]error, len(typs))
+	for j, typ := range typs {
+		collections[j], errs[j] = collected(c, pkgs, typ, collections[j], typeFound[j], err)
+	}
+
+	return collections, errs
+}
+
+// collected finishes collection of typ once the packages have been scanned,
+// typeFound is whether any of them declare typ and err why the scan stopped.
+func collected(c config, pkgs []*packages.Package, typ string, collection Collection, typeFound bool, err error) (Collection, error) {
+	if (c.constrained || c.noCgo) && !c.exportData && err == nil {
+		var errs []error
+		for _, p := range pkgs {
+			errs = append(errs, collectConstrained(c, &collection, p, typ, !c.constrained))
+		}
+		err = errors.Join(errs...)
+	}
+
+	if err == nil && !typeFound {
+		err := &TypeNotFoundError{Type: typ}
+		for _, p := range pkgs {
+			err.Packages = append(err.Packages, p.PkgPath)
+		}
+
+		return Collection{}, err
+	}
+
+	if collection.Type != "" {
+		collection.Module = typeModule(pkgs, collection.Type)
+	}
+
+	// The values comes out in different order and it made some tests flaky
+	sort.SliceStable(collection.Enums, func(i, j int) bool {
+		a, b := collection.Enums[i], collection.Enums[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		if a.Pos.Filename != b.Pos.Filename {
+			return a.Pos.Filename < b.Pos.Filename
+		}
+
+		return a.Pos.Offset < b.Pos.Offset
+	})
+	sort.Slice(collection.Diagnostics, func(i, j int) bool {
+		a, b := collection.Diagnostics[i].Pos, collection.Diagnostics[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		return a.Offset < b.Offset
+	})
+
+	for _, d := range collection.Diagnostics {
+		c.logger.Debug("skipped declaration", "name", d.Name, "pos", d.Pos.String(), "reason", d.Reason)
+	}
+
+	return collection, err
+}
+
+// collectSyntax adds the enums of typ declared in the syntax of p to
+// collection, and returns whether p declares typ.
+func collectSyntax(c config, collection *Collection, p *packages.Package, typ string) bool {
+	var typeFound bool
+	for _, f := range p.Syntax {
+		for _, d := range f.Decls {
+			// Only package level declarations, functions can't declare those
+			gen, ok := d.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if t := p.TypesInfo.Defs[spec.Name]; t != nil && matchesType(t.Type(), typ) {
+						typeFound = true
+					}
+				case *ast.ValueSpec:
+					if args, ok := directive("ignore", gen.Doc, spec.Doc, spec.Comment); ok {
+						c.logger.Debug("ignored declaration", "pos", p.Fset.Position(spec.Pos()).String())
+						for _, name := range spec.Names {
+							if obj := p.TypesInfo.Defs[name]; obj != nil && matchesType(obj.Type(), typ) {
+								collection.Ignored = append(collection.Ignored, newDiagnostic(p, name, args))
+							}
+						}
+						continue
+					}
+
+					n := len(collection.Enums)
+					collectSpec(c, collection, p, gen, spec, typ)
+					c.added(collection, n, p, spec)
+				}
+			}
+		}
+	}
+
+	return typeFound
+}
+
+// collectSpec adds the values of typ declared in spec, part of gen, to collection.
+func collectSpec(c config, collection *Collection, p *packages.Package, gen *ast.GenDecl, spec *ast.ValueSpec, typ string) {
+	if !mayDeclare(spec, typeName(typ)) {
+		return
+	}
+
+	labels := labels(gen, spec)
+	doc := docText(gen, spec)
+
+	for i, name := range spec.Names {
+		t := p.TypesInfo.Defs[name]
+		if t != nil && t.Type() == types.Typ[types.Invalid] && valuesMention(spec, typeName(typ)) {
+			// The package doesn't type check, so this may or may not be a value of the type
+			collection.Diagnostics = append(collection.Diagnostics, newDiagnostic(p, name, typeErrorReason(p, spec)))
+			continue
+		}
+		if t == nil || !matchesType(t.Type(), typ) {
+			continue
+		}
+
+		if call, reg, ok := c.registration(p, spec, i); ok {
+			val, err := reg.value(p, call)
+			if err != nil {
+				collection.Diagnostics = append(collection.Diagnostics, newDiagnostic(p, name, err.Error()))
+				continue
+			}
+
+			collection.add(p, t, "", Enum{Value: val, Doc: doc, Labels: labels})
+			continue
+		}
+
+		if _, ok := t.Type().(*types.Named); !ok {
+			collection.Diagnostics = append(collection.Diagnostics, newDiagnostic(p, name, "type is "+t.Type().String()+", not a value of the type"))
+			continue
+		}
+
+		if types.IsInterface(t.Type()) {
+			val, err := dynamicType(p, spec, i)
+			if err != nil {
+				collection.Diagnostics = append(collection.Diagnostics, newDiagnostic(p, name, err.Error()))
+				continue
+			}
+
+			collection.Interface = true
+			collection.add(p, t, "", Enum{Value: val, Doc: doc, Labels: labels})
+			continue
+		}
+
+		var fieldName string
+		var val string
+		var fields map[string]string
+		var reason string
+		switch {
+		case len(spec.Values) == 0:
+			reason = "declared without a value, implicit repetition and iota are not supported"
+		case len(spec.Values) != len(spec.Names):
+			reason = "assigned from a call returning several values, the value is only known at runtime"
+		default:
+			// Every name has its own value, as in: var A, B Flag = "a", "b"
+			switch value := spec.Values[i].(type) {
+			case *ast.BasicLit:
+				val = value.Value
+			case *ast.CompositeLit:
+				var err error
+				fieldName, val, err = structValue(p, value)
+				if err != nil {
+					reason = err.Error()
+				}
+				fields = structFields(p, value)
+			default:
+				// Constant expressions such as 5 * time.Second are folded by the type checker
+				if tv := p.TypesInfo.Types[value]; gen.Tok == token.CONST && tv.Value != nil && !mentions(value, "iota") {
+					val = constValue(tv.Value)
+					break
+				}
+				if v, ok := extract(value, p.TypesInfo); ok {
+					val = v
+					break
+				}
+
+				// Either a case where it would be hard to distinguish or something not considered so far. Likely the latter.
+				reason = fmt.Sprintf("unsupported expression, please file a bug report with example code if this should be supported: '%T'", value)
+			}
+		}
+
+		if reason != "" {
+			collection.Diagnostics = append(collection.Diagnostics, newDiagnostic(p, name, reason))
+			continue
+		}
+
+		collection.add(p, t, fieldName, Enum{Value: val, Doc: doc, Fields: fields, Labels: labels})
+	}
+}
+
+// added attaches the objects to and filters the enums added to collection
+// from p since it had n enums, spec is the syntax declaring them unless
+// they're read from export data.
+func (c config) added(collection *Collection, n int, p *packages.Package, spec *ast.ValueSpec) {
+	if !c.objects && len(c.filters) == 0 {
+		return
+	}
+
+	kept := collection.Enums[:n]
+	for _, e := range collection.Enums[n:] {
+		obj := p.Types.Scope().Lookup(e.Name)
+		if c.objects {
+			e.Object, e.Spec = obj, spec
+		}
+
+		if !c.keep(e, obj) {
+			c.logger.Debug("filtered declaration", "name", e.Name, "pos", e.Pos.String())
+			continue
+		}
+
+		kept = append(kept, e)
+	}
+	collection.Enums = kept
+}
+
+// keep returns whether every filter keeps e.
+func (c config) keep(e Enum, obj types.Object) bool {
+	for _, filter := range c.filters {
+		if !filter(e, obj) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// valuesMention returns whether the type or any of the values of spec mention name.
+func valuesMention(spec *ast.ValueSpec, name string) bool {
+	if spec.Type != nil && mentions(spec.Type, name) {
+		return true
+	}
+
+	for _, v := range spec.Values {
+		if mentions(v, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// typeErrorReason explains that the type of spec is unknown with the first
+// type error inside of it.
+func typeErrorReason(p *packages.Package, spec *ast.ValueSpec) string {
+	for _, err := range p.TypeErrors {
+		if err.Fset == p.Fset && err.Pos >= spec.Pos() && err.Pos < spec.End() {
+			return "the type is unknown because of a type error: " + err.Msg
+		}
+	}
+
+	return "the type is unknown because of type errors in the package"
+}
+
+// dynamicType returns the name of the concrete type of the value assigned
+// to the i:th name in spec, as it's formatted by the reflect package.
+func dynamicType(p *packages.Package, spec *ast.ValueSpec, i int) (string, error) {
+	if len(spec.Values) != len(spec.Names) {
+		return "", errors.New("declared without a value, the concrete type is unknown")
+	}
+
+	typ := p.TypesInfo.TypeOf(spec.Values[i])
+	if typ == nil || types.IsInterface(typ) {
+		return "", errors.New("the value is an interface, its concrete type is only known at runtime")
+	}
+
+	return types.TypeString(typ, func(p *types.Package) string { return p.Name() }), nil
+}
+
+// docText returns the documentation of spec, falling back to the doc of gen
+// when it only declares spec and then the line comment, without directives.
+func docText(gen *ast.GenDecl, spec *ast.ValueSpec) string {
+	doc := spec.Doc
+	if doc == nil && len(gen.Specs) == 1 {
+		doc = gen.Doc
+	}
+	if doc == nil {
+		doc = spec.Comment
+	}
+
+	return strings.TrimSpace(doc.Text())
+}
+
+// add adds the declaration t from p to the collection as e, with the name,
+// type, and position filled in from t.
+func (c *Collection) add(p *packages.Package, t types.Object, fieldName string, e Enum) {
+	c.Type = t.Type().String()
+	c.FieldName = fieldName
+	e.Name = t.Name()
+	e.Type = t.Type().String()
+	e.Package = p.PkgPath
+	e.Pos = p.Fset.Position(t.Pos())
+	c.Enums = append(c.Enums, e)
+}
+
+// typeModule returns the module of the package declaring typ, which may be
+// another than the module of the enums. When that package is only loaded as
+// a dependency it's the loaded module with the longest path containing it.
+func typeModule(pkgs []*packages.Package, typ string) Module {
+	typ, _, _ = strings.Cut(typ, "[") // the type arguments of a generic type
+	pkgPath := typ[:strings.LastIndex(typ, ".")]
+
+	for _, p := range pkgs {
+		if p.PkgPath == pkgPath && p.Module != nil {
+			return Module{Path: p.Module.Path, Version: p.Module.Version, Dir: p.Module.Dir}
+		}
+	}
+
+	var mod *packages.Module
+	for _, p := range pkgs {
+		if p.Module == nil || (pkgPath != p.Module.Path && !strings.HasPrefix(pkgPath, p.Module.Path+"/")) {
+			continue
+		}
+		if mod == nil || len(p.Module.Path) > len(mod.Path) {
+			mod = p.Module
+		}
+	}
+	if mod == nil {
+		return Module{}
+	}
+
+	return Module{Path: mod.Path, Version: mod.Version, Dir: mod.Dir}
+}
+
+// matchesType returns whether t is, or is built from, the type queried as
+// typ. A fully qualified query with the import path, such as
+// github.com/acme/app/feature.Flag, only matches that exact type, while a
+// shorter one, such as feature.Flag, matches every type ending with it.
+func matchesType(t types.Type, typ string) bool {
+	s := t.String()
+	if !strings.HasSuffix(s, typ) {
+		return false
+	}
+	if !strings.Contains(typ, "/") || len(s) == len(typ) {
+		return true
+	}
+
+	// Pointers, slices, maps, and channels of the type so they can be explained as not being values of it
+	return strings.ContainsRune("*] ", rune(s[len(s)-len(typ)-1]))
+}
+
+// typeName returns the name of typ without any package qualifier.
+func typeName(typ string) string {
+	return typ[strings.LastIndex(typ, ".")+1:]
+}
+
+// mayDeclare is a syntax only check for whether spec could declare values of
+// the type called name, so the type information of specs that can't is never
+// looked up. It errs on the side of caution, any expression whose type can
+// only be known from the type information is assumed to be a match.
+func mayDeclare(spec *ast.ValueSpec, name string) bool {
+	if spec.Type != nil {
+		return mentions(spec.Type, name)
+	}
+
+	for _, v := range spec.Values {
+		switch value := v.(type) {
+		case *ast.BasicLit:
+			// An untyped literal always gets the default type of the literal
+			continue
+		case *ast.CompositeLit:
+			if value.Type != nil && !mentions(value.Type, name) {
+				continue
+			}
+		}
+
+		return true
+	}
+
+	// Implicitly repeated constants get their type from an earlier spec
+	return len(spec.Values) == 0
 }
 
-// Enum represents a value for a matched type.
-//
-// Example:
-//   var MyFlag Flag = "Hello"
-// Is equivalent to:
-//   Enum{Name: "MyFlag", Value: "Hello"}
-type Enum struct {
-	Name  string
-	Value string
+// mentions returns whether an identifier ending with name is used anywhere in
+// node, a suffix to match the same types as the check on the type information.
+func mentions(node ast.Node, name string) bool {
+	var found bool
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && strings.HasSuffix(ident.Name, name) {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
 }
 
-// All finds variables of typ in pkg.
-//
-// Example:
-//   All("./feature", "feature.Flag")
-func All(pkg string, typ string) (Collection, error) {
-	cfg := packages.Config{Mode: packages.NeedTypes | packages.NeedTypesInfo | packages.NeedName}
-	pkgs, err := packages.Load(&cfg, pkg)
-	if err != nil {
-		return Collection{}, fmt.Errorf("failed to load package: %w", err)
+// TypeNotFoundError is returned when no type matching the query is declared
+// in the loaded packages, which most often means there's a typo in the type.
+type TypeNotFoundError struct {
+	Type     string   // the type as queried
+	Packages []string // the import paths of the packages that were searched
+}
+
+func (e *TypeNotFoundError) Error() string {
+	if len(e.Packages) == 0 {
+		return fmt.Sprintf("type %s not found, no packages matched", e.Type)
 	}
 
-	var collection Collection
-	for _, p := range pkgs {
-		for e, t := range p.TypesInfo.Defs {
-			if t == nil {
-				continue
+	return fmt.Sprintf("type %s not found in packages: %s", e.Type, strings.Join(e.Packages, ", "))
+}
+
+// structType returns the declaration of the struct type of the literal exp,
+// found by the position of the type checked object in the syntax of p.
+func structType(p *packages.Package, exp *ast.CompositeLit) (*ast.StructType, error) {
+	named, ok := types.Unalias(p.TypesInfo.TypeOf(exp)).(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("composite literal of %s is not a struct", types.ExprString(exp.Type))
+	}
+	obj := named.Origin().Obj()
+
+	var decl *ast.TypeSpec
+	for _, f := range p.Syntax {
+		if obj.Pos() < f.FileStart || obj.Pos() >= f.FileEnd {
+			continue
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok && spec.Name.Pos() == obj.Pos() {
+				decl = spec
 			}
 
-			if !strings.HasSuffix(t.Type().String(), typ) {
-				continue
+			return decl == nil
+		})
+	}
+	if decl == nil {
+		return nil, fmt.Errorf("struct type not declared in the same package: %s", obj.Type())
+	}
+
+	struc, ok := decl.Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("composite literal of %s is not a struct", obj.Name())
+	}
+
+	return struc, nil
+}
+
+func structValue(p *packages.Package, exp *ast.CompositeLit) (fieldName string, val string, err error) {
+	struc, err := structType(p, exp)
+	if err != nil {
+		return "", "", err
+	}
+
+	var embedded []ast.Expr
+	index := 0 // the position of the field in an unkeyed literal, a field can declare several names
+	for _, f := range struc.Fields.List {
+		if f.Tag != nil && strings.Contains(f.Tag.Value, "`enums:\"identifier\"`") {
+			if len(f.Names) > 1 {
+				return "", "", fmt.Errorf("struct identifier tag is on a field declaring several names: %s", f.Names)
+			}
+			fieldName = embeddedName(f.Type)
+			if len(f.Names) == 1 {
+				fieldName = f.Names[0].String()
+			}
+			if fieldName == "" {
+				return "", "", fmt.Errorf("struct identifier tag is on an embedded field without a name: %s", types.ExprString(f.Type))
 			}
 
-			// A declaration with a value and not a type declaration
-			decl, ok := e.Obj.Decl.(*ast.ValueSpec)
+			elt := fieldElement(exp, index, fieldName)
+			fieldVal, ok := elt.(*ast.BasicLit)
 			if !ok {
-				continue
+				return "", "", fmt.Errorf("struct identifier value not a basic literal: %s = %#v", fieldName, elt)
 			}
 
-			var fieldName string
-			var val string
-			for _, v := range decl.Values {
-				switch value := v.(type) {
-				case *ast.BasicLit:
-					val = value.Value
-				case *ast.CompositeLit:
-					fieldName, val, err = structValue(value)
-					if err != nil {
-						return Collection{}, err
-					}
-				default:
-					// Either a case where it would be hard to distinguish or something not considered so far. Likely the latter.
-					panic(fmt.Sprintf("unknown type, please file a bug report with example code: '%T'", v))
-				}
-			}
+			return fieldName, fieldVal.Value, nil
+		}
 
-			collection.Type = t.Type().String()
-			collection.FieldName = fieldName
-			collection.Enums = append(collection.Enums, Enum{
-				Name:  t.Name(),
-				Value: val,
-			})
+		if len(f.Names) == 0 {
+			embedded = append(embedded, fieldElement(exp, index, embeddedName(f.Type)))
 		}
+
+		index += max(len(f.Names), 1)
 	}
 
-	// The values comes out in different order and it made some tests flaky
-	sort.Slice(collection.Enums, func(i, j int) bool { return collection.Enums[i].Name < collection.Enums[j].Name })
+	// The identifier can be promoted from an embedded struct, in which case
+	// its name is the same as if it was declared on this struct.
+	for _, elt := range embedded {
+		if unary, ok := elt.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			elt = unary.X
+		}
+
+		if inner, ok := elt.(*ast.CompositeLit); ok {
+			if fieldName, val, err := structValue(p, inner); err == nil {
+				return fieldName, val, nil
+			}
+		}
+	}
 
-	return collection, nil
+	return "", "", errors.New(`no struct tag with enum:"identifier" found`)
 }
 
-func structValue(exp *ast.CompositeLit) (fieldName string, val string, err error) {
-	typ := exp.Type.(*ast.Ident)
-	decl := typ.Obj.Decl.(*ast.TypeSpec)
-	struc := decl.Type.(*ast.StructType)
+// structFields returns the source of every field set in the struct literal
+// exp by the field's name. The fields of embedded struct literals are
+// included by the name they're promoted as, unless the outer struct has a
+// field of the same name.
+func structFields(p *packages.Package, exp *ast.CompositeLit) map[string]string {
+	struc, err := structType(p, exp)
+	if err != nil {
+		return nil
+	}
 
-	for i, f := range struc.Fields.List {
-		if strings.Contains(f.Tag.Value, "`enums:\"identifier\"`") {
-			if len(f.Names) > 1 {
-				// No idea if or how this could happen, so let's ask for help
-				panic(fmt.Errorf("struct identifier field has more than one Names, please file a bug report with example code: %#v", f.Names))
-			}
-			fieldName = f.Names[0].String()
+	fields := make(map[string]string)
+	var embedded []ast.Expr
+	index := 0
+	for _, f := range struc.Fields.List {
+		if len(f.Names) == 0 {
+			embedded = append(embedded, fieldElement(exp, index, embeddedName(f.Type)))
+			index++
+			continue
+		}
 
-			kv := exp.Elts[i].(*ast.KeyValueExpr)
-			fieldVal, ok := kv.Value.(*ast.BasicLit)
-			if !ok {
-				return "", "", fmt.Errorf("struct identifier value not a basic literal: %s = %#v", fieldName, kv.Value)
+		for _, name := range f.Names {
+			if elt := fieldElement(exp, index, name.Name); elt != nil {
+				fields[name.Name] = types.ExprString(elt)
 			}
+			index++
+		}
+	}
 
-			val = fieldVal.Value
-			break
+	for _, elt := range embedded {
+		if unary, ok := elt.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			elt = unary.X
+		}
+
+		inner, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+
+		for name, val := range structFields(p, inner) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = val
+			}
 		}
 	}
 
-	if val == "" {
-		return "", "", errors.New(`no struct tag with enum:"identifier" found`)
+	return fields
+}
+
+// fieldElement returns the value of the field name at index in the struct
+// literal exp, or nil if the literal doesn't set the field.
+func fieldElement(exp *ast.CompositeLit, index int, name string) ast.Expr {
+	for i, elt := range exp.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			// An unkeyed literal sets all fields in order
+			if i == index {
+				return elt
+			}
+			continue
+		}
+
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == name {
+			return kv.Value
+		}
 	}
 
-	return fieldName, val, err
+	return nil
+}
+
+// embeddedName returns the field name of an embedded field of type typ.
+func embeddedName(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
 }
 
 // Diff contains the result of checking the difference between a Collection and a list of values.
 type Diff struct {
-	Missing Collection
-	Extra   []string
+	Missing    Collection
+	Extra      []string
+	OutOfOrder []string        // the values of actual that aren't in the order of the enums, only checked with DiffOptions.Ordered
+	Mismatched []FieldMismatch // the fields of struct enums that differ from actual, only checked with DiffOptions.Fields
+
+	// SoftMissing are the enums declared only under build constraints that
+	// aren't part of actual, with DiffOptions.SoftConstrained. They're
+	// output by String but don't make the diff non-zero.
+	SoftMissing Collection
+}
+
+// FieldMismatch is a field of a struct enum set to a different value in the
+// matching value of actual, such as a DefaultOn that was flipped.
+type FieldMismatch struct {
+	Enum     Enum
+	Field    string // the name of the field
+	Declared string // the value of the field as declared, unquoted like the values Diff compares
+	Actual   string // the value of the field in actual
+}
+
+// String outputs the mismatch as Name.Field = declared, actual value.
+func (m FieldMismatch) String() string {
+	return fmt.Sprintf("%s.%s = %s, actual %s", m.Enum.Name, m.Field, m.Declared, m.Actual)
 }
 
 // Zero returns whether there is nothing in the diff.
 func (d Diff) Zero() bool {
-	return len(d.Missing.Enums) == 0 && len(d.Extra) == 0
+	return len(d.Missing.Enums) == 0 && len(d.Extra) == 0 && len(d.OutOfOrder) == 0 && len(d.Mismatched) == 0
 }
 
-// String outputs a human summary of the values in the diff.
+// String outputs a human summary of the values in the diff. Missing enums
+// with a known position say where they're declared, relative to their module.
 func (d Diff) String() string {
 	var msg string
 
 	if len(d.Missing.Enums) > 0 {
-		msg += "Enums declared but not part of actual:\n"
-		for _, v := range d.Missing.Enums {
-			msg += fmt.Sprintf("\t%s = %s\n", v.Name, v.Value)
-		}
+		msg += "Enums declared but not part of actual:\n" + d.Missing.declared()
 	}
 
 	if len(d.Extra) > 0 {
@@ -163,6 +1161,27 @@ func (d Diff) String() string {
 		}
 	}
 
+	if len(d.OutOfOrder) > 0 {
+		msg += "Values provided in a different order than the Enums:\n"
+		for _, v := range d.OutOfOrder {
+			msg += fmt.Sprintf("\t%s\n", v)
+		}
+	}
+
+	if len(d.SoftMissing.Enums) > 0 {
+		msg += "Enums declared under build constraints but not part of actual:\n"
+		for _, v := range d.SoftMissing.Enums {
+			msg += fmt.Sprintf("\t%s = %s (%s)\n", d.SoftMissing.displayName(v), v.Value, v.Constraints)
+		}
+	}
+
+	if len(d.Mismatched) > 0 {
+		msg += "Enums with fields not matching actual:\n"
+		for _, m := range d.Mismatched {
+			msg += fmt.Sprintf("\t%s\n", m)
+		}
+	}
+
 	if len(msg) > 0 {
 		return msg
 	}
@@ -170,28 +1189,197 @@ func (d Diff) String() string {
 	return "<Diff{}>"
 }
 
-// Diff indicates differences between a collection and any slice.
+// declared outputs a line per enum with its value and, when known, where
+// it's declared relative to its module.
+func (c Collection) declared() string {
+	var msg string
+	for _, v := range c.Enums {
+		msg += fmt.Sprintf("\t%s = %s", c.displayName(v), v.Value)
+		if v.Pos.IsValid() {
+			msg += fmt.Sprintf(" (declared at %s:%d)", c.relativeFile(v.Pos.Filename), v.Pos.Line)
+		}
+		msg += "\n"
+	}
+
+	return msg
+}
+
+// Vet outputs the diff in the file:line:col: message format of go vet, so
+// editors and CI can jump straight to the declaration of every enum that
+// isn't handled. handledIn names what was diffed, such as "AllFlags()".
+//
+// Extra values aren't declared anywhere and are output without a position.
+//
+// Example:
+//
+//	feature/flag.go:12:2: Flag value "dark-mode" not handled in AllFlags()
+func (d Diff) Vet(handledIn string) string {
+	name := typeName(d.Missing.Type)
+
+	missing := append([]Enum(nil), d.Missing.Enums...)
+	sort.SliceStable(missing, func(i, j int) bool {
+		a, b := missing[i].Pos, missing[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		return a.Offset < b.Offset
+	})
+
+	var msg string
+	for _, e := range missing {
+		msg += fmt.Sprintf("%s: %s value %s not handled in %s\n", e.Pos, name, e.Value, handledIn)
+	}
+	for _, v := range d.Extra {
+		msg += fmt.Sprintf("%s value %s in %s is not declared\n", name, v, handledIn)
+	}
+	for _, v := range d.OutOfOrder {
+		msg += fmt.Sprintf("%s value %s in %s is out of order\n", name, v, handledIn)
+	}
+	for _, m := range d.Mismatched {
+		msg += fmt.Sprintf("%s: %s value %s has %s = %s in %s, declared as %s\n", m.Enum.Pos, name, m.Enum.Value, m.Field, m.Actual, handledIn, m.Declared)
+	}
+
+	return msg
+}
+
+// ANSI escape codes used by Diff.Unified to color its output.
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// Unified outputs the diff like a unified diff from the declared enums to
+// the actual values, missing enums are removed with - and extra values are
+// added with +. With color the lines are colored red and green with ANSI
+// escape codes, for terminals and CI logs that show them. Values out of
+// order and mismatched fields aren't part of the output.
+//
+// Example:
+//
+//	--- declared
+//	+++ actual
+//	-FlagDarkMode = "dark-mode"
+//	+"light-mode"
+func (d Diff) Unified(color bool) string {
+	if len(d.Missing.Enums) == 0 && len(d.Extra) == 0 {
+		return ""
+	}
+
+	line := func(prefix, code, text string) string {
+		if color {
+			return code + prefix + text + ansiReset + "\n"
+		}
+
+		return prefix + text + "\n"
+	}
+
+	msg := "--- declared\n+++ actual\n"
+	for _, v := range d.Missing.Enums {
+		msg += line("-", ansiRed, fmt.Sprintf("%s = %s", d.Missing.displayName(v), v.Value))
+	}
+	for _, v := range d.Extra {
+		msg += line("+", ansiGreen, v)
+	}
+
+	return msg
+}
+
+// DiffOptions changes how Collection.DiffWith compares the enums to the values.
+type DiffOptions struct {
+	Ordered bool                 // also check that the values are in the same order as the enums, such as for priority lists
+	Less    func(a, b Enum) bool // the order of the enums when Ordered, nil is the order they're declared in
+
+	// SoftConstrained reports the missing enums that are only declared under
+	// build constraints, see WithConstrained, in Diff.SoftMissing instead of
+	// Diff.Missing, for code that only handles the values of the platforms
+	// it's built for.
+	SoftConstrained bool
+
+	// CaseInsensitive compares the values ignoring case, for values that
+	// are normalized to lower or upper case by other systems, such as HTTP
+	// headers or SQL.
+	CaseInsensitive bool
+
+	// Fields also compares the fields set in the literal of struct enums
+	// to the fields of the value of actual with the same identifier. Only
+	// fields set to string, number, and bool literals are compared, as
+	// other expressions can't be evaluated without running the code.
+	Fields bool
+}
+
+// Diff indicates differences between a collection and any slice, or a set
+// modeled as a map where the keys are the values. For a map[T]bool only the
+// keys set to true are part of the set.
 //
 // Because a Collection stores all values as strings the difference is
-// calculated based on the string representation of the value.
+// calculated based on the string representation of the value. String
+// literals are compared by their unquoted value, so a plain []string
+// matches the enums as well as a slice of the type. Pointers and interfaces
+// are compared by the value they point to or hold, so a []*FlagStruct or an
+// []any of values of the type matches struct enums.
+//
+// An enum declared with an //enums:alias-of directive is equivalent to the
+// enum it's an alias of, either value handles both and the alias is never
+// missing on its own.
+//
+// The diff is deterministic: Missing is in the order of the collection and
+// Extra in the order of actual, with the keys of a map sorted.
 func (c Collection) Diff(actual interface{}) Diff {
-	acTyp := reflect.ValueOf(actual)
-	if acTyp.Kind() != reflect.Slice {
-		panic(fmt.Sprintf("Diff: actual is not a slice: %T", actual))
+	return c.DiffWith(actual, DiffOptions{})
+}
+
+// DiffWith is Diff with options for how the values are compared.
+//
+// Example:
+//
+//	flags.DiffWith(feature.ByPriority(), DiffOptions{Ordered: true})
+func (c Collection) DiffWith(actual interface{}, opts DiffOptions) Diff {
+	items := actualItems(actual)
+
+	byName := make(map[string]Enum, len(c.Enums))
+	for _, v := range c.Enums {
+		byName[v.Name] = v
 	}
 
 	values := make(map[string]Enum, len(c.Enums))
+	aliases := make(map[string]string) // the value of an alias to the value of the enum it's an alias of
 	for _, v := range c.Enums {
-		values[v.Value] = v
+		if canonical, ok := byName[v.AliasOf()]; ok {
+			aliases[opts.key(v.Value)] = opts.key(canonical.Value)
+			continue
+		}
+
+		values[opts.key(v.Value)] = v
 	}
 
 	var diff Diff
-	for i := 0; i < acTyp.Len(); i++ {
-		item := acTyp.Index(i)
+	var matched []matchedValue
+	seen := make(map[string]bool)    // the values that have been matched
+	handled := make(map[string]bool) // the enums that have been matched by either their value or an alias
+	for _, item := range items {
 		val := c.valueFrom(item)
 
-		if _, ok := values[val]; ok {
-			delete(values, val)
+		key := opts.key(val)
+		canonical, ok := aliases[key]
+		if !ok {
+			canonical = key
+		}
+
+		if e, ok := values[canonical]; ok {
+			if opts.Fields {
+				diff.Mismatched = append(diff.Mismatched, c.fieldMismatches(e, item)...)
+			}
+			delete(values, canonical)
+			seen[key], handled[canonical] = true, true
+			matched = append(matched, matchedValue{canonical: canonical, val: val})
+			continue
+		}
+
+		// Handling both the alias and the enum it's an alias of is expected during a migration
+		if !seen[key] && handled[canonical] {
+			seen[key] = true
 			continue
 		}
 
@@ -201,18 +1389,196 @@ func (c Collection) Diff(actual interface{}) Diff {
 	diff.Missing = Collection{
 		Type:      c.Type,
 		FieldName: c.FieldName,
+		Module:    c.Module,
+		Interface: c.Interface,
+	}
+	if opts.SoftConstrained {
+		diff.SoftMissing = Collection{
+			Type:      c.Type,
+			FieldName: c.FieldName,
+			Module:    c.Module,
+			Interface: c.Interface,
+		}
+	}
+	// In the order of the collection rather than of the map, so the diff is the same every time
+	for _, v := range c.Enums {
+		missing, ok := values[opts.key(v.Value)]
+		switch {
+		case !ok || missing.Name != v.Name:
+		case opts.SoftConstrained && v.Constraints != "":
+			diff.SoftMissing.Enums = append(diff.SoftMissing.Enums, v)
+		default:
+			diff.Missing.Enums = append(diff.Missing.Enums, v)
+		}
 	}
-	for _, v := range values {
-		diff.Missing.Enums = append(diff.Missing.Enums, v)
+
+	if opts.Ordered {
+		diff.OutOfOrder = c.outOfOrder(matched, handled, byName, opts)
 	}
 
 	return diff
 }
 
+// key returns what val is compared by, its unquoted value folded to lower
+// case when the comparison is case insensitive.
+func (o DiffOptions) key(val string) string {
+	if o.CaseInsensitive {
+		return strings.ToLower(Unquote(val))
+	}
+
+	return Unquote(val)
+}
+
+// matchedValue is a value of actual that matched an enum, by the unquoted
+// value of the enum or the enum it's an alias of.
+type matchedValue struct {
+	canonical string
+	val       string
+}
+
+// outOfOrder returns the values of matched that aren't where they'd be if
+// the handled enums were sorted by less, or by where they're declared.
+func (c Collection) outOfOrder(matched []matchedValue, handled map[string]bool, byName map[string]Enum, opts DiffOptions) []string {
+	var expected []Enum
+	added := make(map[string]bool)
+	for _, e := range c.Enums {
+		key := opts.key(e.Value)
+		if _, alias := byName[e.AliasOf()]; alias || !handled[key] || added[key] {
+			continue
+		}
+
+		added[key] = true
+		expected = append(expected, e)
+	}
+
+	less := opts.Less
+	if less == nil {
+		less = func(a, b Enum) bool {
+			if a.Pos.Filename != b.Pos.Filename {
+				return a.Pos.Filename < b.Pos.Filename
+			}
+
+			return a.Pos.Offset < b.Pos.Offset
+		}
+	}
+	sort.SliceStable(expected, func(i, j int) bool { return less(expected[i], expected[j]) })
+
+	var outOfOrder []string
+	for i, m := range matched {
+		if m.canonical != opts.key(expected[i].Value) {
+			outOfOrder = append(outOfOrder, m.val)
+		}
+	}
+
+	return outOfOrder
+}
+
+// actualItems returns the items of a slice, or the keys of a map used as a set.
+func actualItems(actual interface{}) []reflect.Value {
+	acTyp := reflect.ValueOf(actual)
+
+	switch acTyp.Kind() {
+	case reflect.Slice:
+		items := make([]reflect.Value, acTyp.Len())
+		for i := range items {
+			items[i] = acTyp.Index(i)
+		}
+
+		return items
+	case reflect.Map:
+		onlyTrue := acTyp.Type().Elem().Kind() == reflect.Bool
+
+		var items []reflect.Value
+		iter := acTyp.MapRange()
+		for iter.Next() {
+			if onlyTrue && !iter.Value().Bool() {
+				continue
+			}
+
+			items = append(items, iter.Key())
+		}
+
+		// Maps are iterated in random order, sort so any extra values are always reported the same way
+		sort.Slice(items, func(i, j int) bool {
+			return fmt.Sprintf("%#v", items[i].Interface()) < fmt.Sprintf("%#v", items[j].Interface())
+		})
+
+		return items
+	default:
+		panic(fmt.Sprintf("Diff: actual is not a slice or map: %T", actual))
+	}
+}
+
+// Unquote returns the value of a literal as written in Enum.Value the way
+// Values and Diff compare it. String literals are unquoted, so "\x41", `A`
+// and "A" are all A. Rune and float literals are returned as the fmt
+// package formats their values, so '\t' is 9 and 1.0 is 1. Anything else is
+// returned as is.
+func Unquote(val string) string {
+	if r, ok := runeLit(val); ok {
+		return strconv.Itoa(int(r))
+	}
+	if f, ok := floatLit(val); ok {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	if !strings.HasPrefix(val, `"`) && !strings.HasPrefix(val, "`") {
+		return val
+	}
+
+	if s, err := strconv.Unquote(val); err == nil {
+		return s
+	}
+
+	return val
+}
+
+// runeLit returns the rune of a rune literal such as 'a' or '\t', and whether val is one.
+func runeLit(val string) (rune, bool) {
+	if !strings.HasPrefix(val, "'") {
+		return 0, false
+	}
+
+	s, err := strconv.Unquote(val)
+	if err != nil {
+		return 0, false
+	}
+
+	return []rune(s)[0], true
+}
+
+// floatLit returns the value of a decimal float literal such as 1.0 or 2e3, and whether val is one.
+func floatLit(val string) (float64, bool) {
+	if !strings.ContainsAny(val, ".eE") || strings.HasPrefix(val, "0x") || strings.HasPrefix(val, "0X") {
+		return 0, false
+	}
+
+	f, err := strconv.ParseFloat(strings.ReplaceAll(val, "_", ""), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return f, true
+}
+
 func (c Collection) valueFrom(item reflect.Value) string {
 	var val string
 
+	// Registries of struct enums often hold pointers to them, or any values
+	// of several types, compare what they point to or hold
+	for !c.Interface && (item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface) {
+		if item.IsNil() {
+			return "nil"
+		}
+		item = item.Elem()
+	}
+
 	switch item.Type().Kind() {
+	case reflect.Interface:
+		if item.IsNil() {
+			val = "nil"
+		} else {
+			val = item.Elem().Type().String()
+		}
 	case reflect.Struct:
 		val = c.fieldValue(item)
 
@@ -226,6 +1592,66 @@ func (c Collection) valueFrom(item reflect.Value) string {
 	return val
 }
 
+// fieldMismatches returns the fields of e set to a literal whose value
+// differs in item, in the order of the field names.
+func (c Collection) fieldMismatches(e Enum, item reflect.Value) []FieldMismatch {
+	for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+		if item.IsNil() {
+			return nil
+		}
+		item = item.Elem()
+	}
+	if item.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var mismatches []FieldMismatch
+	for _, name := range slices.Sorted(maps.Keys(e.Fields)) {
+		if name == c.FieldName {
+			continue // already matched by Diff
+		}
+
+		field := item.FieldByName(name)
+		if !field.IsValid() {
+			continue
+		}
+
+		declared, actual, ok := literalField(e.Fields[name], field)
+		if ok && declared != actual {
+			mismatches = append(mismatches, FieldMismatch{Enum: e, Field: name, Declared: declared, Actual: actual})
+		}
+	}
+
+	return mismatches
+}
+
+// literalField returns src and the value of field formatted the same way,
+// and whether src is a literal of the kind of field so they can be compared.
+func literalField(src string, field reflect.Value) (declared, actual string, ok bool) {
+	switch field.Kind() {
+	case reflect.String:
+		s, err := strconv.Unquote(src)
+		return s, field.String(), err == nil
+	case reflect.Bool:
+		return src, strconv.FormatBool(field.Bool()), src == "true" || src == "false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(strings.ReplaceAll(src, "_", ""), 0, 64)
+		return strconv.FormatInt(i, 10), strconv.FormatInt(field.Int(), 10), err == nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(strings.ReplaceAll(src, "_", ""), 0, 64)
+		return strconv.FormatUint(u, 10), strconv.FormatUint(field.Uint(), 10), err == nil
+	case reflect.Float32, reflect.Float64:
+		f, ok := floatLit(src)
+		if !ok {
+			i, err := strconv.ParseInt(src, 0, 64)
+			f, ok = float64(i), err == nil
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), strconv.FormatFloat(field.Float(), 'g', -1, 64), ok
+	default:
+		return "", "", false
+	}
+}
+
 func (c Collection) fieldValue(item reflect.Value) string {
 	if len(c.Enums) == 0 {
 		panic("Diff: collection is empty")
@@ -237,6 +1663,7 @@ func (c Collection) fieldValue(item reflect.Value) string {
 		return ""
 	}
 
+	// Also finds fields promoted from embedded structs
 	field, ok := typ.FieldByName(c.FieldName)
 	if !ok {
 		return ""
@@ -246,5 +1673,11 @@ func (c Collection) fieldValue(item reflect.Value) string {
 		return ""
 	}
 
-	return fmt.Sprintf("%#v", item.FieldByName(c.FieldName).Interface())
+	// Errors when the field is promoted through a nil embedded pointer
+	val, err := item.FieldByIndexErr(field.Index)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%#v", val.Interface())
 }
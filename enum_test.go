@@ -1,13 +1,26 @@
 package enums_test
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
 
 	"github.com/gaqzi/enums"
+	"github.com/gaqzi/enums/testdata/embeddedtag"
 )
 
 func TestAll(t *testing.T) {
@@ -18,18 +31,38 @@ func TestAll(t *testing.T) {
 		require.Empty(t, matches, "expected to not have found any matches")
 	})
 
+	t.Run("returns an error when the type isn't declared", func(t *testing.T) {
+		_, err := enums.All("./testdata/singlematch", "singlematch.Falg")
+
+		var notFound *enums.TypeNotFoundError
+		require.ErrorAs(t, err, &notFound, "expected a typo in the type to be an error")
+		require.Equal(
+			t,
+			&enums.TypeNotFoundError{
+				Type:     "singlematch.Falg",
+				Packages: []string{"github.com/gaqzi/enums/testdata/singlematch"},
+			},
+			notFound,
+		)
+		require.EqualError(t, err, "type singlematch.Falg not found in packages: github.com/gaqzi/enums/testdata/singlematch")
+	})
+
 	t.Run("when one match found return it", func(t *testing.T) {
 		matches, err := enums.All("./testdata/singlematch", "singlematch.Flag")
 		require.NoError(t, err, "error when scanning testdata/singlematch")
+		matches.Enums = withoutPos(matches.Enums)
 
 		require.Equal(
 			t,
 			enums.Collection{
-				Type: "github.com/gaqzi/enums/testdata/singlematch.Flag",
+				Type:   "github.com/gaqzi/enums/testdata/singlematch.Flag",
+				Module: mainModule(t),
 				Enums: []enums.Enum{
 					{
-						Name:  "FlagSomethingCouldBe",
-						Value: `"flag-whatever"`,
+						Name:    "FlagSomethingCouldBe",
+						Value:   `"flag-whatever"`,
+						Type:    "github.com/gaqzi/enums/testdata/singlematch.Flag",
+						Package: "github.com/gaqzi/enums/testdata/singlematch",
 					},
 				},
 			},
@@ -41,19 +74,27 @@ func TestAll(t *testing.T) {
 	t.Run("returns all matches found", func(t *testing.T) {
 		matches, err := enums.All("./testdata/multimatch", "multimatch.Flag")
 		require.NoError(t, err, "error when scanning testdata/multimatch")
+		matches.Enums = withoutPos(matches.Enums)
 
 		require.Equal(
 			t,
 			enums.Collection{
-				Type: "github.com/gaqzi/enums/testdata/multimatch.Flag",
+				Type:   "github.com/gaqzi/enums/testdata/multimatch.Flag",
+				Module: mainModule(t),
 				Enums: []enums.Enum{
 					{
-						Name:  "FlagSomethingCouldBe",
-						Value: `"flag-whatever"`,
+						Name:    "FlagSomethingCouldBe",
+						Value:   `"flag-whatever"`,
+						Type:    "github.com/gaqzi/enums/testdata/multimatch.Flag",
+						Package: "github.com/gaqzi/enums/testdata/multimatch",
+						Doc:     "FlagSomethingCouldBe is documented\nover two lines.",
 					},
 					{
-						Name:  "FlagSomethingElse",
-						Value: `"flag-whomever"`,
+						Name:    "FlagSomethingElse",
+						Value:   `"flag-whomever"`,
+						Type:    "github.com/gaqzi/enums/testdata/multimatch.Flag",
+						Package: "github.com/gaqzi/enums/testdata/multimatch",
+						Doc:     "with a line comment",
 					},
 				},
 			},
@@ -61,6 +102,319 @@ func TestAll(t *testing.T) {
 			"expected to have gotten back a single match",
 		)
 	})
+
+	t.Run("pairs every name with its value when several are declared together", func(t *testing.T) {
+		matches, err := enums.All("./testdata/multiname", "multiname.Flag")
+		require.NoError(t, err)
+
+		values := make(map[string]string)
+		for _, e := range matches.Enums {
+			values[e.Name] = e.Value
+		}
+		require.Equal(
+			t,
+			map[string]string{
+				"FlagA": `"flag-a"`,
+				"FlagB": `"flag-b"`,
+				"FlagC": `"flag-c"`,
+				"FlagD": `"flag-d"`,
+			},
+			values,
+		)
+
+		var skipped []string
+		for _, d := range matches.Diagnostics {
+			skipped = append(skipped, d.Name+": "+d.Reason)
+		}
+		require.Equal(
+			t,
+			[]string{
+				"FlagE: assigned from a call returning several values, the value is only known at runtime",
+				"FlagF: assigned from a call returning several values, the value is only known at runtime",
+			},
+			skipped,
+		)
+	})
+
+	t.Run("records where each match is declared", func(t *testing.T) {
+		matches, err := enums.All("./testdata/multimatch", "multimatch.Flag")
+		require.NoError(t, err)
+
+		file, err := filepath.Abs("testdata/multimatch/example.go")
+		require.NoError(t, err)
+		require.Equal(t, token.Position{Filename: file, Offset: 104, Line: 8, Column: 2}, matches.Enums[0].Pos)
+		require.Equal(t, token.Position{Filename: file, Offset: 149, Line: 9, Column: 2}, matches.Enums[1].Pos)
+	})
+}
+
+// withoutPos returns a copy of es without their positions, for comparing
+// enums found in different checkouts or with different load modes.
+func withoutPos(es []enums.Enum) []enums.Enum {
+	stripped := make([]enums.Enum, len(es))
+	for i, e := range es {
+		e.Pos = token.Position{}
+		stripped[i] = e
+	}
+
+	return stripped
+}
+
+// mainModule is the module the tests are run from.
+func mainModule(t *testing.T) enums.Module {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	return enums.Module{Path: "github.com/gaqzi/enums", Dir: wd}
+}
+
+func TestCollection_All(t *testing.T) {
+	collection := enums.Collection{
+		Enums: []enums.Enum{
+			{Name: "FlagA", Value: `"a"`},
+			{Name: "FlagB", Value: `"b"`},
+		},
+	}
+
+	t.Run("yields every enum in order", func(t *testing.T) {
+		var seen []enums.Enum
+		for e := range collection.All() {
+			seen = append(seen, e)
+		}
+
+		require.Equal(t, collection.Enums, seen)
+	})
+
+	t.Run("stops when the loop breaks", func(t *testing.T) {
+		var seen []enums.Enum
+		for e := range collection.All() {
+			seen = append(seen, e)
+			break
+		}
+
+		require.Equal(t, collection.Enums[:1], seen)
+	})
+}
+
+func TestCollection_Accessors(t *testing.T) {
+	newCollection := func() enums.Collection {
+		return enums.Collection{
+			Type: "feature.Flag",
+			Enums: []enums.Enum{
+				{Name: "FlagA", Value: `"a"`, Labels: map[string]string{"group": "payments"}},
+				{Name: "FlagB", Value: `"b"`},
+			},
+		}
+	}
+
+	t.Run("Len and At return the enums", func(t *testing.T) {
+		collection := newCollection()
+
+		require.Equal(t, 2, collection.Len())
+		require.Equal(t, collection.Enums[1], collection.At(1))
+	})
+
+	t.Run("At returns a copy of the enum", func(t *testing.T) {
+		collection := newCollection()
+
+		collection.At(0).Labels["group"] = "changed"
+
+		require.Equal(t, newCollection(), collection)
+	})
+
+	t.Run("With adds enums without changing the collection", func(t *testing.T) {
+		collection := newCollection()
+
+		with := collection.With(enums.Enum{Name: "FlagC", Value: `"c"`})
+		with.Enums[0].Labels["group"] = "changed"
+
+		require.Equal(t, []string{"a", "b", "c"}, with.Values())
+		require.Equal(t, newCollection(), collection)
+	})
+
+	t.Run("Filter keeps the matching enums without changing the collection", func(t *testing.T) {
+		collection := newCollection()
+
+		filtered := collection.Filter(func(e enums.Enum) bool { return e.Labels["group"] == "payments" })
+		filtered.Enums[0].Labels["group"] = "changed"
+
+		require.Equal(t, []string{"a"}, filtered.Values())
+		require.Equal(t, "feature.Flag", filtered.Type)
+		require.Equal(t, newCollection(), collection)
+	})
+}
+
+func TestCollection_GroupBy(t *testing.T) {
+	matches, err := enums.All("./testdata/group", "group.Flag")
+	require.NoError(t, err)
+
+	groups := make(map[string][]string)
+	for name, c := range matches.GroupBy("group") {
+		require.Equal(t, matches.Type, c.Type)
+		for _, e := range c.Enums {
+			groups[name] = append(groups[name], e.Name)
+		}
+	}
+	require.Equal(
+		t,
+		map[string][]string{
+			"payments": {"FlagInvoices", "FlagRefunds"},
+			"checkout": {"FlagOneClick"},
+			"":         {"FlagDarkMode"},
+		},
+		groups,
+		"a directive on a spec takes precedence over the one on its declaration",
+	)
+
+	diff := matches.GroupBy("group")["payments"].Diff([]string{"refunds", "invoices"})
+	require.Truef(t, diff.Zero(), "expected no differences: %s", diff)
+}
+
+func TestCollection_DiffWith_Ordered(t *testing.T) {
+	collection := enums.Collection{
+		Type: "enums_test.val",
+		Enums: []enums.Enum{
+			{Name: "High", Value: `"high"`, Pos: token.Position{Filename: "priority.go", Offset: 10}},
+			{Name: "Low", Value: `"low"`, Pos: token.Position{Filename: "priority.go", Offset: 30}},
+			{Name: "Medium", Value: `"medium"`, Pos: token.Position{Filename: "priority.go", Offset: 20}},
+		},
+	}
+	ordered := enums.DiffOptions{Ordered: true}
+
+	t.Run("passes when the values are in the order the enums are declared", func(t *testing.T) {
+		diff := collection.DiffWith([]string{"high", "medium", "low"}, ordered)
+
+		require.True(t, diff.Zero(), diff.String())
+	})
+
+	t.Run("reports the values out of order", func(t *testing.T) {
+		diff := collection.DiffWith([]string{"high", "low", "medium"}, ordered)
+
+		require.Equal(t, []string{`"low"`, `"medium"`}, diff.OutOfOrder)
+	})
+
+	t.Run("only orders the values that are handled", func(t *testing.T) {
+		diff := collection.DiffWith([]string{"high", "low"}, ordered)
+
+		require.Empty(t, diff.OutOfOrder)
+		require.Equal(t, []string{"medium"}, diff.Missing.Values())
+	})
+
+	t.Run("orders by Less", func(t *testing.T) {
+		byName := enums.DiffOptions{Ordered: true, Less: func(a, b enums.Enum) bool { return a.Name < b.Name }}
+
+		require.Empty(t, collection.DiffWith([]string{"high", "low", "medium"}, byName).OutOfOrder)
+		require.Equal(t, []string{`"medium"`, `"low"`}, collection.DiffWith([]string{"high", "medium", "low"}, byName).OutOfOrder)
+	})
+
+	t.Run("doesn't check the order by default", func(t *testing.T) {
+		require.True(t, collection.Diff([]string{"low", "medium", "high"}).Zero())
+	})
+}
+
+func TestCollection_DiffWith_CaseInsensitive(t *testing.T) {
+	collection := enums.Collection{
+		Type: "enums_test.val",
+		Enums: []enums.Enum{
+			{Name: "HeaderContentType", Value: `"Content-Type"`},
+			{Name: "HeaderUserAgent", Value: `"User-Agent"`},
+		},
+	}
+
+	t.Run("matches values differing in case", func(t *testing.T) {
+		diff := collection.DiffWith([]string{"content-type", "USER-AGENT"}, enums.DiffOptions{CaseInsensitive: true})
+
+		require.True(t, diff.Zero(), diff.String())
+	})
+
+	t.Run("still reports missing and extra values", func(t *testing.T) {
+		diff := collection.DiffWith([]string{"content-type", "accept"}, enums.DiffOptions{CaseInsensitive: true})
+
+		require.Equal(t, []string{"User-Agent"}, diff.Missing.Values())
+		require.Equal(t, []string{`"accept"`}, diff.Extra)
+	})
+
+	t.Run("is case sensitive by default", func(t *testing.T) {
+		diff := collection.Diff([]string{"content-type", "User-Agent"})
+
+		require.Equal(t, []string{"Content-Type"}, diff.Missing.Values())
+	})
+}
+
+func TestCollection_DiffWith_Fields(t *testing.T) {
+	type flag struct {
+		Name      string `enums:"identifier"`
+		DefaultOn bool
+		Rollout   float64
+		Owner     string
+	}
+	collection := enums.Collection{
+		Type:      "enums_test.flag",
+		FieldName: "Name",
+		Enums: []enums.Enum{
+			{
+				Name:   "FlagDarkMode",
+				Value:  `"dark-mode"`,
+				Fields: map[string]string{"Name": `"dark-mode"`, "DefaultOn": "true", "Rollout": "0.5", "Owner": "owners.Web"},
+			},
+		},
+	}
+	fields := enums.DiffOptions{Fields: true}
+
+	t.Run("passes when the fields match", func(t *testing.T) {
+		diff := collection.DiffWith([]flag{{Name: "dark-mode", DefaultOn: true, Rollout: 0.5}}, fields)
+
+		require.True(t, diff.Zero(), diff.String())
+	})
+
+	t.Run("reports the fields that differ", func(t *testing.T) {
+		diff := collection.DiffWith([]*flag{{Name: "dark-mode", DefaultOn: false, Rollout: 1}}, fields)
+
+		require.Equal(
+			t,
+			[]enums.FieldMismatch{
+				{Enum: collection.Enums[0], Field: "DefaultOn", Declared: "true", Actual: "false"},
+				{Enum: collection.Enums[0], Field: "Rollout", Declared: "0.5", Actual: "1"},
+			},
+			diff.Mismatched,
+			"fields set to expressions other than literals aren't compared",
+		)
+	})
+
+	t.Run("doesn't compare the fields by default", func(t *testing.T) {
+		require.True(t, collection.Diff([]flag{{Name: "dark-mode"}}).Zero())
+	})
+}
+
+func TestCollection_Diff_Aliases(t *testing.T) {
+	matches, err := enums.All("./testdata/alias", "alias.Flag")
+	require.NoError(t, err)
+	require.Equal(t, "FlagCheckout", matches.Enums[1].AliasOf(), "expected FlagCheckoutLegacy to be an alias")
+
+	for _, tc := range []struct {
+		name    string
+		actual  []string
+		missing []string
+		extra   []string
+	}{
+		{name: "the enum handles its alias", actual: []string{"checkout-v2", "search"}},
+		{name: "the alias handles the enum", actual: []string{"checkout", "search"}},
+		{name: "both can be handled during a migration", actual: []string{"checkout", "checkout-v2", "search"}},
+		{name: "the alias is never missing on its own", actual: []string{"search"}, missing: []string{"FlagCheckout"}},
+		{name: "duplicates are still extra", actual: []string{"checkout", "checkout", "search"}, extra: []string{`"checkout"`}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := matches.Diff(tc.actual)
+
+			var missing []string
+			for _, e := range diff.Missing.Enums {
+				missing = append(missing, e.Name)
+			}
+			require.Equal(t, tc.missing, missing)
+			require.Equal(t, tc.extra, diff.Extra)
+		})
+	}
 }
 
 func TestCollection_Diff(t *testing.T) {
@@ -77,7 +431,7 @@ func TestCollection_Diff(t *testing.T) {
 			enums.Collection{
 				Type: "enums_test.val",
 				Enums: []enums.Enum{
-					{"test", `"hello"`},
+					{Name: "test", Value: `"hello"`},
 				},
 			}.Diff([]val{test}),
 			"expected the same values to have no diff",
@@ -91,20 +445,31 @@ func TestCollection_Diff(t *testing.T) {
 				Missing: enums.Collection{
 					Type: "enums_test.val",
 					Enums: []enums.Enum{
-						{"test", `"hello"`},
+						{Name: "test", Value: `"hello"`},
 					},
 				},
 			},
 			enums.Collection{
 				Type: "enums_test.val",
 				Enums: []enums.Enum{
-					{"test", `"hello"`},
+					{Name: "test", Value: `"hello"`},
 				},
 			}.Diff([]val{}),
 			"expected a diff message",
 		)
 	})
 
+	t.Run("missing values are in the order of the collection", func(t *testing.T) {
+		collection := enums.Collection{Type: "enums_test.val"}
+		for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+			collection.Enums = append(collection.Enums, enums.Enum{Name: name, Value: strconv.Quote(name)})
+		}
+
+		for i := 0; i < 10; i++ {
+			require.Equal(t, collection.Enums, collection.Diff([]val{}).Missing.Enums)
+		}
+	})
+
 	t.Run("extra values are shown", func(t *testing.T) {
 		require.Equal(
 			t,
@@ -114,6 +479,55 @@ func TestCollection_Diff(t *testing.T) {
 		)
 	})
 
+	t.Run("compares string literals by their unquoted value", func(t *testing.T) {
+		collection := enums.Collection{
+			Type: "enums_test.val",
+			Enums: []enums.Enum{
+				{Name: "quoted", Value: `"flag-whatever"`},
+				{Name: "raw", Value: "`flag-raw`"},
+				{Name: "escaped", Value: `"flag-\x41"`},
+			},
+		}
+
+		require.Equal(
+			t,
+			enums.Diff{Missing: enums.Collection{Type: "enums_test.val"}},
+			collection.Diff([]string{"flag-whatever", "flag-raw", "flag-A"}),
+			"expected plain strings to match the literals",
+		)
+	})
+
+	t.Run("handles sets", func(t *testing.T) {
+		collection := enums.Collection{
+			Type: "enums_test.val",
+			Enums: []enums.Enum{
+				{Name: "test", Value: `"hello"`},
+				{Name: "other", Value: `"other"`},
+			},
+		}
+
+		t.Run("uses the keys of a map with empty struct values", func(t *testing.T) {
+			require.Equal(
+				t,
+				enums.Diff{
+					Missing: enums.Collection{Type: "enums_test.val", Enums: []enums.Enum{{Name: "other", Value: `"other"`}}},
+					Extra:   []string{`"m000"`},
+				},
+				collection.Diff(map[val]struct{}{test: {}, "m000": {}}),
+			)
+		})
+
+		t.Run("only uses the keys set to true of a map with bool values", func(t *testing.T) {
+			require.Equal(
+				t,
+				enums.Diff{
+					Missing: enums.Collection{Type: "enums_test.val", Enums: []enums.Enum{{Name: "other", Value: `"other"`}}},
+				},
+				collection.Diff(map[val]bool{test: true, "other": false}),
+			)
+		})
+	})
+
 	t.Run("handles structs", func(t *testing.T) {
 		type testStruct struct {
 			FieldA string `enums:"identifier"`
@@ -123,7 +537,7 @@ func TestCollection_Diff(t *testing.T) {
 			Type:      "enums_test.testStruct",
 			FieldName: "FieldA",
 			Enums: []enums.Enum{
-				{"test", `"Hello"`},
+				{Name: "test", Value: `"Hello"`},
 			},
 		}
 
@@ -141,6 +555,22 @@ func TestCollection_Diff(t *testing.T) {
 			)
 		})
 
+		t.Run("uses the struct a pointer points to", func(t *testing.T) {
+			diff := collection.Diff([]*testStruct{&test, nil})
+
+			require.Empty(t, diff.Missing.Enums)
+			require.Equal(t, []string{"nil"}, diff.Extra)
+		})
+
+		t.Run("uses the values held by interfaces", func(t *testing.T) {
+			require.True(t, collection.Diff([]interface{}{test}).Zero())
+
+			diff := collection.Diff([]interface{}{&test, "other", nil})
+
+			require.Empty(t, diff.Missing.Enums)
+			require.Equal(t, []string{`"other"`, "nil"}, diff.Extra)
+		})
+
 		t.Run("doesn't match fields for other struct types", func(t *testing.T) {
 			type otherStruct struct {
 				FieldA string `enums:"identifier"`
@@ -171,7 +601,7 @@ func TestDiff(t *testing.T) {
 
 		require.ElementsMatch(
 			t,
-			[]string{"Missing", "Extra"}, // All handled fields
+			[]string{"Missing", "Extra", "OutOfOrder", "Mismatched", "SoftMissing"}, // All handled fields
 			allFields,
 			"when a need field is added to Diff remember to update the test cases below to handle them",
 		)
@@ -203,12 +633,52 @@ func TestDiff(t *testing.T) {
 			expected: "Enums declared but not part of actual:\n" +
 				"\tFlagSomething = flag-something\n",
 		},
+		{
+			name: "Missing with a position says where it's declared",
+			diff: enums.Diff{Missing: enums.Collection{
+				Type:   "feature.Flag",
+				Module: enums.Module{Dir: "/src/app"},
+				Enums: []enums.Enum{
+					{
+						Name:  "FlagSomething",
+						Value: "flag-something",
+						Pos:   token.Position{Filename: "/src/app/feature/flags.go", Line: 12, Column: 2},
+					},
+				},
+			}},
+			expected: "Enums declared but not part of actual:\n" +
+				"\tFlagSomething = flag-something (declared at feature/flags.go:12)\n",
+		},
 		{
 			name: "Extra is set",
 			diff: enums.Diff{Extra: []string{"hello"}},
 			expected: "Extra values provided but not part of Enums:\n" +
 				"\thello\n",
 		},
+		{
+			name: "OutOfOrder is set",
+			diff: enums.Diff{OutOfOrder: []string{"b", "a"}},
+			expected: "Values provided in a different order than the Enums:\n" +
+				"\tb\n" +
+				"\ta\n",
+		},
+		{
+			name: "Mismatched is set",
+			diff: enums.Diff{Mismatched: []enums.FieldMismatch{
+				{Enum: enums.Enum{Name: "FlagDefaultOn"}, Field: "DefaultOn", Declared: "true", Actual: "false"},
+			}},
+			expected: "Enums with fields not matching actual:\n" +
+				"\tFlagDefaultOn.DefaultOn = true, actual false\n",
+		},
+		{
+			name: "SoftMissing is set",
+			diff: enums.Diff{SoftMissing: enums.Collection{
+				Enums: []enums.Enum{{Name: "ShellPowerShell", Value: `"powershell"`, Constraints: "windows"}},
+			}},
+			expected: "Enums declared under build constraints but not part of actual:\n" +
+				"\tShellPowerShell = \"powershell\" (windows)\n",
+			isZero: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -221,3 +691,661 @@ func TestDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestDiff_Unified(t *testing.T) {
+	diff := enums.Diff{
+		Missing: enums.Collection{Type: "full.Flag", Enums: []enums.Enum{{Name: "FlagSomething", Value: `"flag-something"`}}},
+		Extra:   []string{`"hello"`},
+	}
+
+	t.Run("removes missing enums and adds extra values", func(t *testing.T) {
+		require.Equal(
+			t,
+			"--- declared\n+++ actual\n"+
+				"-FlagSomething = \"flag-something\"\n"+
+				"+\"hello\"\n",
+			diff.Unified(false),
+		)
+	})
+
+	t.Run("colors the lines with ANSI escape codes", func(t *testing.T) {
+		require.Equal(
+			t,
+			"--- declared\n+++ actual\n"+
+				"\x1b[31m-FlagSomething = \"flag-something\"\x1b[0m\n"+
+				"\x1b[32m+\"hello\"\x1b[0m\n",
+			diff.Unified(true),
+		)
+	})
+
+	t.Run("is empty when there's no difference", func(t *testing.T) {
+		require.Empty(t, enums.Diff{}.Unified(true))
+	})
+}
+
+func TestDiff_Vet(t *testing.T) {
+	collection, err := enums.All("./testdata/multimatch", "multimatch.Flag")
+	require.NoError(t, err)
+	file, err := filepath.Abs("testdata/multimatch/example.go")
+	require.NoError(t, err)
+
+	t.Run("outputs the position of missing enums and extra values", func(t *testing.T) {
+		diff := collection.Diff([]string{"flag-nobody"})
+
+		require.Equal(
+			t,
+			file+`:8:2: Flag value "flag-whatever" not handled in AllFlags()`+"\n"+
+				file+`:9:2: Flag value "flag-whomever" not handled in AllFlags()`+"\n"+
+				`Flag value "flag-nobody" in AllFlags() is not declared`+"\n",
+			diff.Vet("AllFlags()"),
+		)
+	})
+
+	t.Run("is empty without differences", func(t *testing.T) {
+		require.Empty(t, collection.Diff([]string{"flag-whatever", "flag-whomever"}).Vet("AllFlags()"))
+	})
+}
+
+func TestAll_Runes(t *testing.T) {
+	matches, err := enums.All("./testdata/keys", "keys.Key")
+	require.NoError(t, err)
+
+	t.Run("keeps the literal as the value and has the rune", func(t *testing.T) {
+		require.Equal(t, "Tab", matches.Enums[3].Name)
+		require.Equal(t, `'\t'`, matches.Enums[3].Value)
+
+		r, ok := matches.Enums[3].Rune()
+		require.True(t, ok)
+		require.Equal(t, '\t', r)
+	})
+
+	t.Run("diffs against runes", func(t *testing.T) {
+		diff := matches.Diff([]rune{'\t', '\n', ' ', 'b'})
+
+		require.Equal(t, []string{"97"}, diff.Missing.Values(), "expected LetterA to be missing")
+		require.Equal(t, []string{"98"}, diff.Extra)
+	})
+
+	t.Run("isn't a rune for other values", func(t *testing.T) {
+		_, ok := enums.Enum{Value: `"a"`}.Rune()
+
+		require.False(t, ok)
+	})
+}
+
+func TestEnum_Unquoted(t *testing.T) {
+	for literal, expected := range map[string]string{
+		`"on"`:   "on",
+		"`on`":   "on",
+		`"\x41"`: "A",
+		`'\t'`:   "9",
+		`1.0`:    "1",
+		`42`:     "42",
+	} {
+		t.Run(literal, func(t *testing.T) {
+			require.Equal(t, expected, enums.Enum{Value: literal}.Unquoted())
+			require.Equal(t, expected, enums.Unquote(literal))
+		})
+	}
+}
+
+func TestAll_Floats(t *testing.T) {
+	matches, err := enums.All("./testdata/version", "version.Version")
+	require.NoError(t, err)
+
+	t.Run("keeps the literal as the value", func(t *testing.T) {
+		require.Equal(t, "1.0", matches.Enums[0].Value)
+	})
+
+	t.Run("diffs against floats", func(t *testing.T) {
+		diff := matches.Diff([]float64{1, 1.5, 2.5})
+
+		require.Equal(t, []string{"2"}, diff.Missing.Values())
+		require.Equal(t, []string{"2.5"}, diff.Extra)
+	})
+
+	t.Run("has the same values when reading export data", func(t *testing.T) {
+		exported, err := enums.All("./testdata/version", "version.Version", enums.WithExportData())
+		require.NoError(t, err)
+
+		require.Equal(t, matches.Values(), exported.Values())
+	})
+}
+
+func TestAll_ConstantExpressions(t *testing.T) {
+	matches, err := enums.All("./testdata/timeout", "timeout.Timeout")
+	require.NoError(t, err)
+
+	t.Run("folds the expressions into their values", func(t *testing.T) {
+		require.Empty(t, matches.Diagnostics)
+		require.Equal(t, []string{"150000000000", "60000000000", "5000000000"}, matches.Values())
+	})
+
+	t.Run("diffs against durations", func(t *testing.T) {
+		diff := matches.Diff([]time.Duration{5 * time.Second, time.Minute, 150 * time.Second})
+
+		require.True(t, diff.Zero(), diff.String())
+	})
+}
+
+func TestAll_StructsDeclaredInOtherFiles(t *testing.T) {
+	matches, err := enums.All("./testdata/split", "split.Plan")
+	require.NoError(t, err)
+
+	require.Empty(t, matches.Diagnostics)
+	require.Equal(t, []string{"free", "pro"}, matches.Values())
+	require.Equal(t, map[string]string{"ID": `"pro"`, "Price": "10"}, matches.Enums[1].Fields)
+}
+
+func TestAll_SameNameInSeveralPackages(t *testing.T) {
+	matches, err := enums.All("./testdata/sharedtype/...", "events.Type")
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range matches.Diff([]string{}).Missing.Enums {
+		names = append(names, path.Base(e.Package)+"."+e.Name)
+	}
+
+	require.Equal(t, []string{"orders.EventCreated", "billing.EventRefunded", "orders.EventRefunded"}, names, "expected enums of the same name to be ordered by package")
+}
+
+func TestAll_IdentifierOnEmbeddedField(t *testing.T) {
+	matches, err := enums.All("./testdata/embeddedtag", "embeddedtag.Flag")
+	require.NoError(t, err)
+
+	require.Empty(t, matches.Diagnostics)
+	require.Equal(t, "Name", matches.FieldName)
+	require.Equal(t, []string{"off", "on"}, matches.Values())
+	require.True(t, matches.Diff(embeddedtag.AllFlags()).Zero())
+}
+
+func TestAll_IgnoreDirective(t *testing.T) {
+	matches, err := enums.All("./testdata/ignore", "ignore.Flag")
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range matches.Enums {
+		names = append(names, e.Name)
+	}
+	require.Equal(t, []string{"FlagIgnored", "FlagOff", "FlagOn"}, names, "declarations with //enums:ignore are left out")
+	require.Empty(t, matches.Diagnostics, "ignored declarations aren't diagnostics")
+
+	var ignored []string
+	for _, d := range matches.Ignored {
+		ignored = append(ignored, d.Name+": "+d.Reason)
+	}
+	require.Equal(t, []string{"flagTestOnly: only used to check the zero value in tests", "flagInternal: ", "FlagDeprecated: "}, ignored)
+}
+
+func TestAll_LoadErrors(t *testing.T) {
+	t.Run("returns an error when the directory doesn't exist", func(t *testing.T) {
+		_, err := enums.All("./testdata/doesnotexist", "doesnotexist.Flag")
+
+		require.ErrorContains(t, err, "failed to load package: ./testdata/doesnotexist: ")
+		require.ErrorContains(t, err, "directory not found")
+		var pkgErr packages.Error
+		require.ErrorAs(t, err, &pkgErr)
+	})
+
+	t.Run("returns TypeNotFoundError when the pattern matches no packages", func(t *testing.T) {
+		_, err := enums.All("./testdata/i18n/...", "i18n.Flag")
+
+		var notFound *enums.TypeNotFoundError
+		require.ErrorAs(t, err, &notFound)
+		require.EqualError(t, err, "type i18n.Flag not found, no packages matched")
+	})
+
+	t.Run("returns an error when an import doesn't resolve", func(t *testing.T) {
+		_, err := enums.All("./testdata/badimport", "badimport.Flag")
+
+		require.ErrorContains(t, err, "failed to load package: github.com/gaqzi/enums/testdata/badimport: ")
+		require.ErrorContains(t, err, "could not import github.com/gaqzi/enums/testdata/doesnotexist")
+	})
+
+	t.Run("returns the enums of the packages that loaded with the errors", func(t *testing.T) {
+		matches, err := enums.All("./testdata/partial/...", "partial.Flag")
+
+		require.ErrorContains(t, err, "failed to load package: github.com/gaqzi/enums/testdata/partial/broken: ")
+		require.Equal(t, []string{"off", "on"}, matches.Values())
+	})
+}
+
+func TestAll_TypeErrors(t *testing.T) {
+	matches, err := enums.All("./testdata/typeerror", "typeerror.Flag")
+	require.NoError(t, err, "expected type errors in the package to be tolerated")
+
+	var names []string
+	for _, e := range matches.Enums {
+		names = append(names, e.Name)
+	}
+	require.Equal(t, []string{"FlagOff", "FlagOn"}, names, "expected the declarations that type check to be collected")
+
+	var skipped []string
+	for _, d := range matches.Diagnostics {
+		skipped = append(skipped, d.Name+": "+d.Reason)
+	}
+	require.Equal(
+		t,
+		[]string{
+			"FlagConverted: the type is unknown because of a type error: undefined: undefinedVariable",
+			"FlagFromCall: the type is unknown because of a type error: undefined: newFlag",
+		},
+		skipped,
+	)
+}
+
+func TestAll_Packages(t *testing.T) {
+	matches, err := enums.All("./testdata/multipkg/...", "multipkg.Flag")
+	require.NoError(t, err)
+
+	t.Run("records the package each enum is declared in", func(t *testing.T) {
+		var pkgs []string
+		for _, e := range matches.Enums {
+			pkgs = append(pkgs, e.Package)
+		}
+
+		require.Equal(t, []string{"github.com/gaqzi/enums/testdata/multipkg", "github.com/gaqzi/enums/testdata/multipkg/legacy"}, pkgs)
+	})
+
+	t.Run("qualifies enums with the same name by their package in diffs", func(t *testing.T) {
+		diff := matches.Diff([]string{})
+
+		require.Equal(
+			t,
+			"Enums declared but not part of actual:\n"+
+				"\tmultipkg.FlagOn = \"on\" (declared at testdata/multipkg/example.go:5)\n"+
+				"\tlegacy.FlagOn = \"legacy-on\" (declared at testdata/multipkg/legacy/legacy.go:6)\n",
+			diff.String(),
+		)
+	})
+}
+
+func TestAll_Diagnostics(t *testing.T) {
+	file, err := filepath.Abs("testdata/diagnostics/example.go")
+	require.NoError(t, err)
+
+	t.Run("explains skipped values and slices of the type", func(t *testing.T) {
+		matches, err := enums.All("./testdata/diagnostics", "diagnostics.Flag")
+		require.NoError(t, err)
+
+		require.Equal(
+			t,
+			[]enums.Enum{{Name: "FlagValid", Value: `"flag-valid"`, Type: "github.com/gaqzi/enums/testdata/diagnostics.Flag", Package: "github.com/gaqzi/enums/testdata/diagnostics"}},
+			withoutPos(matches.Enums),
+		)
+		require.Equal(
+			t,
+			[]enums.Diagnostic{
+				{
+					Name:   "FlagComputed",
+					Pos:    token.Position{Filename: file, Offset: 83, Line: 8, Column: 2},
+					Reason: "unsupported expression, please file a bug report with example code if this should be supported: '*ast.CallExpr'",
+				},
+				{
+					Name:   "AllFlags",
+					Pos:    token.Position{Filename: file, Offset: 126, Line: 9, Column: 2},
+					Reason: "type is []github.com/gaqzi/enums/testdata/diagnostics.Flag, not a value of the type",
+				},
+			},
+			matches.Diagnostics,
+		)
+		require.Equal(
+			t,
+			file+":8:2: FlagComputed skipped: "+matches.Diagnostics[0].Reason,
+			matches.Diagnostics[0].String(),
+		)
+	})
+
+	t.Run("explains structs without an identifier tag", func(t *testing.T) {
+		matches, err := enums.All("./testdata/diagnostics", "diagnostics.Untagged")
+		require.NoError(t, err)
+
+		require.Empty(t, matches.Enums)
+		require.Len(t, matches.Diagnostics, 1)
+		require.Equal(t, "UntaggedValue", matches.Diagnostics[0].Name)
+		require.Equal(t, `no struct tag with enum:"identifier" found`, matches.Diagnostics[0].Reason)
+	})
+
+	t.Run("explains an identifier tag on a field declaring several names", func(t *testing.T) {
+		matches, err := enums.All("./testdata/diagnostics", "diagnostics.Pair")
+		require.NoError(t, err)
+
+		require.Empty(t, matches.Enums)
+		require.Len(t, matches.Diagnostics, 1)
+		require.Equal(t, "struct identifier tag is on a field declaring several names: [Left Right]", matches.Diagnostics[0].Reason)
+	})
+
+	t.Run("explains iota and implicitly repeated constants", func(t *testing.T) {
+		matches, err := enums.All("./testdata/diagnostics", "diagnostics.Stage")
+		require.NoError(t, err)
+
+		require.Empty(t, matches.Enums)
+		require.Len(t, matches.Diagnostics, 2)
+		require.Equal(t, "StageOne", matches.Diagnostics[0].Name)
+		require.Equal(t, "unsupported expression, please file a bug report with example code if this should be supported: '*ast.Ident'", matches.Diagnostics[0].Reason)
+		require.Equal(t, "StageTwo", matches.Diagnostics[1].Name)
+		require.Equal(t, "declared without a value, implicit repetition and iota are not supported", matches.Diagnostics[1].Reason)
+	})
+}
+
+func TestCollection_Err(t *testing.T) {
+	t.Run("has a ScanError for every skipped declaration", func(t *testing.T) {
+		matches, err := enums.All("./testdata/diagnostics", "diagnostics.Stage")
+		require.NoError(t, err)
+		file, err := filepath.Abs("testdata/diagnostics/example.go")
+		require.NoError(t, err)
+
+		err = matches.Err()
+
+		var scanErr *enums.ScanError
+		require.ErrorAs(t, err, &scanErr)
+		require.Equal(t, "StageOne", scanErr.Name)
+		require.Equal(t, file, scanErr.Pos.Filename)
+		require.Equal(t, file+":21: StageOne: "+matches.Diagnostics[0].Reason+"\n"+file+":22: StageTwo: "+matches.Diagnostics[1].Reason, err.Error())
+	})
+
+	t.Run("is nil when nothing was skipped", func(t *testing.T) {
+		matches, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+
+		require.NoError(t, matches.Err())
+	})
+}
+
+func TestAll_WithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	_, err := enums.All("./testdata/diagnostics", "diagnostics.Flag", enums.WithLogger(logger))
+	require.NoError(t, err)
+
+	require.Contains(t, buf.String(), `msg="loaded packages" pattern=./testdata/diagnostics`)
+	require.Contains(t, buf.String(), `msg="scanning package" package=github.com/gaqzi/enums/testdata/diagnostics type=diagnostics.Flag`)
+	require.Contains(t, buf.String(), `msg="skipped declaration" name=FlagComputed`)
+}
+
+func TestAll_WithProgress(t *testing.T) {
+	var progress []string
+	_, err := enums.All("./testdata/multipkg/...", "multipkg.Flag", enums.WithProgress(func(done, total int, pkg string) {
+		progress = append(progress, fmt.Sprintf("%d/%d %s", done, total, pkg))
+	}))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"0/2 ",
+		"1/2 github.com/gaqzi/enums/testdata/multipkg",
+		"2/2 github.com/gaqzi/enums/testdata/multipkg/legacy",
+	}, progress)
+}
+
+func TestAll_WithTimeout(t *testing.T) {
+	t.Run("returns an error when loading takes too long", func(t *testing.T) {
+		_, err := enums.All("./testdata/multimatch", "multimatch.Flag", enums.WithTimeout(time.Nanosecond))
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("returns the values found so far when scanning takes too long", func(t *testing.T) {
+		pkgs, err := packages.Load(&packages.Config{Mode: enums.LoadMode}, "./testdata/multimatch")
+		require.NoError(t, err)
+
+		matches, err := enums.FromPackages(pkgs, "multimatch.Flag", enums.WithTimeout(time.Nanosecond))
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Empty(t, matches.Enums, "expected to have timed out before scanning the only package")
+	})
+
+	t.Run("no error when finishing in time", func(t *testing.T) {
+		_, err := enums.All("./testdata/multimatch", "multimatch.Flag", enums.WithTimeout(time.Minute))
+
+		require.NoError(t, err)
+	})
+}
+
+func TestAll_WithObjects(t *testing.T) {
+	t.Run("attaches the object and syntax of every enum", func(t *testing.T) {
+		matches, err := enums.All("./testdata/full", "full.Flag", enums.WithObjects())
+		require.NoError(t, err)
+
+		for _, e := range matches.Enums {
+			require.IsType(t, &types.Const{}, e.Object, e.Name)
+			require.Equal(t, e.Name, e.Object.Name())
+			require.Equal(t, e.Name, e.Spec.Names[0].Name)
+		}
+	})
+
+	t.Run("only attaches the object when reading export data", func(t *testing.T) {
+		matches, err := enums.All("./testdata/full", "full.Flag", enums.WithObjects(), enums.WithExportData())
+		require.NoError(t, err)
+
+		require.NotEmpty(t, matches.Enums)
+		for _, e := range matches.Enums {
+			require.Equal(t, e.Name, e.Object.Name())
+			require.Nil(t, e.Spec)
+		}
+	})
+
+	t.Run("leaves them unset by default", func(t *testing.T) {
+		matches, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+
+		for _, e := range matches.Enums {
+			require.Nil(t, e.Object)
+			require.Nil(t, e.Spec)
+		}
+	})
+}
+
+func TestAll_WithEnv(t *testing.T) {
+	t.Run("loads the packages with the environment", func(t *testing.T) {
+		matches, err := enums.All("./testdata/platform", "platform.Shell", enums.WithEnv(append(os.Environ(), "GOOS=windows")))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"ShellPowerShell", "ShellSh"}, []string{matches.Enums[0].Name, matches.Enums[1].Name})
+	})
+
+	t.Run("uses the current environment by default", func(t *testing.T) {
+		t.Setenv("GOOS", "linux")
+
+		matches, err := enums.All("./testdata/platform", "platform.Shell")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"sh"}, matches.Values())
+	})
+}
+
+func TestAll_WithConstrained(t *testing.T) {
+	t.Setenv("GOOS", "linux")
+	matches, err := enums.All("./testdata/platform", "platform.Shell", enums.WithConstrained())
+	require.NoError(t, err)
+
+	t.Run("finds the enums declared in files excluded from the build", func(t *testing.T) {
+		var constraints []string
+		for _, e := range matches.Enums {
+			constraints = append(constraints, e.Name+": "+e.Constraints)
+		}
+
+		require.Equal(t, []string{"ShellPowerShell: windows", "ShellRc: plan9 || (linux && rc)", "ShellSh: "}, constraints)
+		require.Equal(t, "ShellRc is the shell of Plan 9.", matches.Enums[1].Doc)
+	})
+
+	t.Run("reports the constrained enums as soft missing", func(t *testing.T) {
+		diff := matches.DiffWith([]string{"sh"}, enums.DiffOptions{SoftConstrained: true})
+
+		require.True(t, diff.Zero(), diff.String())
+		require.Equal(t, []string{"powershell", "rc"}, diff.SoftMissing.Values())
+	})
+
+	t.Run("reports the constrained enums as missing by default", func(t *testing.T) {
+		diff := matches.Diff([]string{"sh"})
+
+		require.Equal(t, []string{"powershell", "rc"}, diff.Missing.Values())
+	})
+
+	t.Run("filters the enums declared in files excluded from the build", func(t *testing.T) {
+		matches, err := enums.All("./testdata/platform", "platform.Shell", enums.WithConstrained(), enums.WithFilter(func(e enums.Enum, obj types.Object) bool {
+			return e.Name != "ShellRc"
+		}))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"powershell", "sh"}, matches.Values())
+	})
+
+	t.Run("only finds the enums of the current build by default", func(t *testing.T) {
+		matches, err := enums.All("./testdata/platform", "platform.Shell")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"sh"}, matches.Values())
+	})
+}
+
+func TestAll_WithoutCgo(t *testing.T) {
+	t.Run("scans the files using cgo without type checking them", func(t *testing.T) {
+		matches, err := enums.All("./testdata/cgo", "cgo.Codec", enums.WithoutCgo())
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"go", "native"}, matches.Values())
+		require.Equal(t, "cgo", matches.Enums[1].Constraints)
+	})
+
+	t.Run("doesn't scan files excluded by other build constraints", func(t *testing.T) {
+		t.Setenv("GOOS", "linux")
+		matches, err := enums.All("./testdata/platform", "platform.Shell", enums.WithoutCgo())
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"sh"}, matches.Values())
+	})
+}
+
+func TestAll_WithFilter(t *testing.T) {
+	t.Run("only keeps the enums the filter returns true for", func(t *testing.T) {
+		var objects []string
+		matches, err := enums.All("./testdata/full", "full.Flag", enums.WithFilter(func(e enums.Enum, obj types.Object) bool {
+			objects = append(objects, obj.Name())
+			return e.Name != "DeployOneThing"
+		}))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"deploy-all-the-things"}, matches.Values())
+		require.ElementsMatch(t, []string{"DeployAllTheThings", "DeployOneThing"}, objects)
+	})
+
+	t.Run("filters when reading export data", func(t *testing.T) {
+		matches, err := enums.All("./testdata/full", "full.Flag", enums.WithExportData(), enums.WithFilter(func(e enums.Enum, _ types.Object) bool {
+			return e.Name != "DeployOneThing"
+		}))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"deploy-all-the-things"}, matches.Values())
+	})
+}
+
+func TestAllAtRev(t *testing.T) {
+	t.Run("scans the package as checked in at the revision", func(t *testing.T) {
+		expected, err := enums.All("./testdata/multimatch", "multimatch.Flag")
+		require.NoError(t, err)
+
+		matches, err := enums.AllAtRev("HEAD", "./testdata/multimatch", "multimatch.Flag")
+		require.NoError(t, err, "error when scanning testdata/multimatch at HEAD")
+
+		require.Equal(t, expected.Type, matches.Type)
+		require.Equal(t, withoutPos(expected.Enums), withoutPos(matches.Enums), "expected the same values as the current checkout")
+		require.Equal(t, expected.Module.Path, matches.Module.Path)
+		require.NotEqual(t, expected.Module.Dir, matches.Module.Dir, "expected to have been scanned in a separate worktree")
+	})
+
+	t.Run("returns an error when the revision doesn't exist", func(t *testing.T) {
+		_, err := enums.AllAtRev("does-not-exist", "./testdata/multimatch", "multimatch.Flag")
+
+		require.Error(t, err)
+	})
+}
+
+func TestAll_Concurrent(t *testing.T) {
+	expected, err := enums.All("./testdata/multimatch", "multimatch.Flag")
+	require.NoError(t, err)
+
+	results := make([]enums.Collection, 10)
+	errs := make([]error, len(results))
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			results[i], errs[i] = enums.All("./testdata/multimatch", "multimatch.Flag")
+			if errs[i] == nil {
+				results[i].Enums[0].Value = "changed" // must not leak into the other results
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	expected.Enums[0].Value = "changed"
+	for i, r := range results {
+		require.NoError(t, errs[i])
+		require.Equal(t, expected, r, "expected every concurrent scan to get its own copy of the same result")
+	}
+}
+
+func TestFromPackages(t *testing.T) {
+	t.Run("finds matches in already loaded packages", func(t *testing.T) {
+		expected, err := enums.All("./testdata/multimatch", "multimatch.Flag")
+		require.NoError(t, err)
+
+		pkgs, err := packages.Load(&packages.Config{Mode: enums.LoadMode}, "./testdata/multimatch")
+		require.NoError(t, err)
+
+		matches, err := enums.FromPackages(pkgs, "multimatch.Flag")
+		require.NoError(t, err)
+
+		require.Equal(t, expected, matches)
+	})
+
+	t.Run("returns an error when the packages are missing syntax or type information", func(t *testing.T) {
+		pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName}, "./testdata/multimatch")
+		require.NoError(t, err)
+
+		_, err = enums.FromPackages(pkgs, "multimatch.Flag")
+
+		require.EqualError(t, err, "package github.com/gaqzi/enums/testdata/multimatch is missing syntax or type information, load it with enums.LoadMode")
+	})
+
+	t.Run("only matches the exact type when fully qualified", func(t *testing.T) {
+		pkgs, err := packages.Load(&packages.Config{Mode: enums.LoadMode}, "./testdata/singlematch", "./testdata/multimatch")
+		require.NoError(t, err)
+
+		matches, err := enums.FromPackages(pkgs, "github.com/gaqzi/enums/testdata/multimatch.Flag")
+		require.NoError(t, err)
+
+		for _, e := range matches.Enums {
+			require.Equal(t, "github.com/gaqzi/enums/testdata/multimatch.Flag", e.Type)
+		}
+		require.Len(t, matches.Enums, 2)
+
+		_, err = enums.FromPackages(pkgs, "github.com/gaqzi/enums/testdata/match.Flag")
+		var notFound *enums.TypeNotFoundError
+		require.ErrorAs(t, err, &notFound, "expected a fully qualified type to not match by suffix")
+	})
+
+	t.Run("records the type of every match when several types match", func(t *testing.T) {
+		pkgs, err := packages.Load(&packages.Config{Mode: enums.LoadMode}, "./testdata/singlematch", "./testdata/multimatch")
+		require.NoError(t, err)
+
+		matches, err := enums.FromPackages(pkgs, "match.Flag")
+		require.NoError(t, err)
+
+		types := make(map[string][]string)
+		for _, e := range matches.Enums {
+			types[e.Type] = append(types[e.Type], e.Name)
+		}
+		require.Equal(
+			t,
+			map[string][]string{
+				"github.com/gaqzi/enums/testdata/singlematch.Flag": {"FlagSomethingCouldBe"},
+				"github.com/gaqzi/enums/testdata/multimatch.Flag":  {"FlagSomethingCouldBe", "FlagSomethingElse"},
+			},
+			types,
+		)
+	})
+}
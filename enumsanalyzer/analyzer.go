@@ -0,0 +1,256 @@
+// Package enumsanalyzer checks that the switches and slice literals in
+// declarations marked with an //enums:exhaustive directive handle every
+// constant of an enum type, and suggests fixes adding the missing ones so
+// editors and linters run with -fix can patch the drift.
+//
+// Only declarations with the directive are checked, switches everywhere
+// else are left to https://github.com/nishanths/exhaustive.
+//
+// Example:
+//
+//	//enums:exhaustive
+//	func AllFlags() []Flag {
+//		return []Flag{FlagOn, FlagOff}
+//	}
+package enumsanalyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzer reports the constants of an enum type missing from the switches
+// and slice literals of declarations marked with //enums:exhaustive.
+var Analyzer = &analysis.Analyzer{
+	Name: "enums",
+	Doc:  "check that declarations marked with //enums:exhaustive handle every constant of an enum type",
+	URL:  "https://pkg.go.dev/github.com/gaqzi/enums/enumsanalyzer",
+	Run:  run,
+}
+
+// directive marks the declarations that are checked.
+const directive = "//enums:exhaustive"
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	for _, f := range pass.Files {
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if marked(d.Doc) && d.Body != nil {
+					check(pass, f, d.Body)
+				}
+			case *ast.GenDecl:
+				if marked(d.Doc) {
+					check(pass, f, d)
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// marked returns whether doc has the directive.
+func marked(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, c := range doc.List {
+		if c.Text == directive || strings.HasPrefix(c.Text, directive+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// check reports the switches and slice literals of enum types in node that
+// are missing constants.
+func check(pass *analysis.Pass, f *ast.File, node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SwitchStmt:
+			if n.Tag == nil {
+				return true
+			}
+
+			var handled []ast.Expr
+			for _, stmt := range n.Body.List {
+				clause := stmt.(*ast.CaseClause)
+				if clause.List == nil {
+					return true // a default clause handles the rest
+				}
+				handled = append(handled, clause.List...)
+			}
+
+			report(pass, f, "switch", pass.TypesInfo.TypeOf(n.Tag), handled, n.Pos(), func(names []string) analysis.TextEdit {
+				indent := strings.Repeat("\t", pass.Fset.Position(n.Pos()).Column-1)
+				var b strings.Builder
+				for _, name := range names {
+					fmt.Fprintf(&b, "%scase %s:\n%s\t// TODO: handle %s\n", indent, name, indent, name)
+				}
+
+				return insertLine(pass, n.Body.Rbrace, b.String())
+			})
+		case *ast.CompositeLit:
+			lit := pass.TypesInfo.TypeOf(n)
+			if lit == nil {
+				return true
+			}
+
+			var elem types.Type
+			switch t := lit.Underlying().(type) {
+			case *types.Slice:
+				elem = t.Elem()
+			case *types.Array:
+				elem = t.Elem()
+			default:
+				return true
+			}
+
+			report(pass, f, "slice", elem, n.Elts, n.Pos(), func(names []string) analysis.TextEdit {
+				if pass.Fset.Position(n.Lbrace).Line == pass.Fset.Position(n.Rbrace).Line {
+					if len(n.Elts) == 0 {
+						return analysis.TextEdit{Pos: n.Rbrace, End: n.Rbrace, NewText: []byte(strings.Join(names, ", "))}
+					}
+
+					end := n.Elts[len(n.Elts)-1].End()
+					return analysis.TextEdit{Pos: end, End: end, NewText: []byte(", " + strings.Join(names, ", "))}
+				}
+
+				indent := strings.Repeat("\t", pass.Fset.Position(n.Rbrace).Column)
+				var b strings.Builder
+				for _, name := range names {
+					fmt.Fprintf(&b, "%s%s, // TODO: check %s belongs here\n", indent, name, name)
+				}
+
+				return insertLine(pass, n.Rbrace, b.String())
+			})
+		}
+
+		return true
+	})
+}
+
+// report reports the constants of typ not in handled at pos, with a fix
+// made by edit from the names to add as they're written in f.
+func report(pass *analysis.Pass, f *ast.File, kind string, typ types.Type, handled []ast.Expr, pos token.Pos, edit func(names []string) analysis.TextEdit) {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return
+	}
+
+	seen := make(map[types.Object]bool)
+	for _, expr := range handled {
+		if obj := constOf(pass, expr); obj != nil {
+			seen[obj] = true
+		}
+	}
+
+	qualifier, canFix := qualifier(pass, f, named.Obj().Pkg())
+	var missing, names []string
+	for _, c := range members(pass, named) {
+		if seen[c] {
+			continue
+		}
+		missing = append(missing, c.Name())
+		names = append(names, qualifier+c.Name())
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	d := analysis.Diagnostic{
+		Pos:     pos,
+		Message: fmt.Sprintf("%s of %s is missing %s", kind, named.Obj().Name(), strings.Join(missing, ", ")),
+	}
+	if canFix {
+		d.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Add " + strings.Join(names, ", "),
+			TextEdits: []analysis.TextEdit{edit(names)},
+		}}
+	}
+	pass.Report(d)
+}
+
+// members returns the constants of named declared in its package that can
+// be referred to from the package being analyzed, in the order they're
+// declared.
+func members(pass *analysis.Pass, named *types.Named) []*types.Const {
+	pkg := named.Obj().Pkg()
+	scope := pkg.Scope()
+
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) || (pkg != pass.Pkg && !c.Exported()) {
+			continue
+		}
+		consts = append(consts, c)
+	}
+
+	// Names are sorted, the order of declaration is what people read in the source
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+
+	return consts
+}
+
+// constOf returns the constant expr refers to, if any.
+func constOf(pass *analysis.Pass, expr ast.Expr) types.Object {
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+
+	if c, ok := pass.TypesInfo.Uses[ident].(*types.Const); ok {
+		return c
+	}
+
+	return nil
+}
+
+// qualifier returns what the names of pkg are prefixed with in f, and
+// whether pkg is imported by f so the names can be written at all.
+func qualifier(pass *analysis.Pass, f *ast.File, pkg *types.Package) (string, bool) {
+	if pkg == pass.Pkg {
+		return "", true
+	}
+
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || path != pkg.Path() {
+			continue
+		}
+
+		if spec.Name == nil {
+			return pkg.Name() + ".", true
+		}
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return "", spec.Name.Name == "."
+		}
+
+		return spec.Name.Name + ".", true
+	}
+
+	return "", false
+}
+
+// insertLine inserts text on a line of its own before the line of pos.
+func insertLine(pass *analysis.Pass, pos token.Pos, text string) analysis.TextEdit {
+	start := pos - token.Pos(pass.Fset.Position(pos).Column-1)
+
+	return analysis.TextEdit{Pos: start, End: start, NewText: []byte(text)}
+}
@@ -0,0 +1,13 @@
+package enumsanalyzer_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/gaqzi/enums/enumsanalyzer"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), enumsanalyzer.Analyzer, "a")
+}
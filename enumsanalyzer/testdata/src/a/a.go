@@ -0,0 +1,52 @@
+package a
+
+import "feature"
+
+type Color int
+
+const (
+	Red   Color = 1
+	Green Color = 2
+	Blue  Color = 3
+)
+
+//enums:exhaustive
+func name(c Color) string {
+	switch c { // want `switch of Color is missing Green, Blue`
+	case Red:
+		return "red"
+	}
+
+	return ""
+}
+
+//enums:exhaustive
+func AllColors() []Color {
+	return []Color{Red} // want `slice of Color is missing Green, Blue`
+}
+
+//enums:exhaustive
+var flags = []feature.Flag{ // want `slice of Flag is missing FlagBeta`
+	feature.FlagOn,
+	feature.FlagOff,
+}
+
+//enums:exhaustive
+func withDefault(c Color) bool {
+	switch c {
+	case Red:
+		return true
+	default:
+		return false
+	}
+}
+
+// Not marked so never checked
+func unchecked(c Color) bool {
+	switch c {
+	case Red:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,9 @@
+package feature
+
+type Flag string
+
+const (
+	FlagOn   Flag = "on"
+	FlagOff  Flag = "off"
+	FlagBeta Flag = "beta"
+)
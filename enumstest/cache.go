@@ -0,0 +1,63 @@
+package enumstest
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gaqzi/enums"
+)
+
+// cache holds the results of enums.All for the lifetime of the test binary,
+// as suites calling NoDiff in many subtests would otherwise load the same
+// packages over and over.
+var cache = struct {
+	sync.Mutex
+	collections map[[2]string]enums.Collection
+	disabled    int32 // the number of tests currently disabling the cache
+}{collections: make(map[[2]string]enums.Collection)}
+
+// DisableCache makes the helpers load the packages on every call until the
+// test t and its subtests have finished, for when the packages are changed
+// while the tests are running.
+func DisableCache(t testing.TB) {
+	atomic.AddInt32(&cache.disabled, 1)
+	t.Cleanup(func() { atomic.AddInt32(&cache.disabled, -1) })
+}
+
+// all is enums.All with the results cached by pkg and typ.
+func all(pkg, typ string) (enums.Collection, error) {
+	return cached([2]string{pkg, typ}, func() (enums.Collection, error) { return enums.All(pkg, typ) })
+}
+
+// allOf is enums.AllOf with the results cached by the package and type of T.
+func allOf[T any]() (enums.Collection, error) {
+	typ := reflect.TypeFor[T]()
+	return cached([2]string{typ.PkgPath(), typ.String()}, func() (enums.Collection, error) { return enums.AllOf[T]() })
+}
+
+// cached returns the collection cached for key, or loads and caches it.
+func cached(key [2]string, load func() (enums.Collection, error)) (enums.Collection, error) {
+	if atomic.LoadInt32(&cache.disabled) > 0 {
+		return load()
+	}
+
+	cache.Lock()
+	collection, ok := cache.collections[key]
+	cache.Unlock()
+	if ok {
+		return collection, nil
+	}
+
+	collection, err := load()
+	if err != nil {
+		return collection, err
+	}
+
+	cache.Lock()
+	cache.collections[key] = collection
+	cache.Unlock()
+
+	return collection, nil
+}
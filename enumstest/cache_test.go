@@ -0,0 +1,47 @@
+package enumstest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("caches collections by package and type", func(t *testing.T) {
+		first, err := all("../testdata/full", "full.Flag")
+		require.NoError(t, err)
+
+		cache.Lock()
+		cached, ok := cache.collections[[2]string{"../testdata/full", "full.Flag"}]
+		cache.Unlock()
+
+		require.True(t, ok, "expected the collection to have been cached")
+		require.Equal(t, first, cached)
+	})
+
+	t.Run("doesn't cache when disabled", func(t *testing.T) {
+		t.Run("disabled", func(t *testing.T) {
+			DisableCache(t)
+
+			_, err := all("../testdata/full", "full.FlagStruct")
+			require.NoError(t, err)
+
+			cache.Lock()
+			_, ok := cache.collections[[2]string{"../testdata/full", "full.FlagStruct"}]
+			cache.Unlock()
+			require.False(t, ok, "expected nothing to be cached while disabled")
+		})
+
+		require.Zero(t, cache.disabled, "expected the cache to be enabled again after the test")
+	})
+
+	t.Run("doesn't cache errors", func(t *testing.T) {
+		_, err := all("../testdata/full", "full.Falg")
+		require.Error(t, err)
+
+		cache.Lock()
+		_, ok := cache.collections[[2]string{"../testdata/full", "full.Falg"}]
+		cache.Unlock()
+		require.False(t, ok)
+	})
+}
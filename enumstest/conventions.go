@@ -0,0 +1,60 @@
+package enumstest
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gaqzi/enums"
+)
+
+// NamesMatch asserts that the name of every enum of typ in pkg matches re,
+// to enforce naming conventions alongside the values being handled.
+//
+// Example:
+//
+//	NamesMatch(t, "./feature", "feature.Flag", regexp.MustCompile(`^Flag`))
+func NamesMatch(t tHelper, pkg, typ string, re *regexp.Regexp) bool {
+	t.Helper()
+
+	return allMatch(t, pkg, typ, re, "names", func(e enums.Enum) string { return e.Name })
+}
+
+// ValuesMatch asserts that the value of every enum of typ in pkg matches
+// re, with string literals unquoted, to catch malformed values such as flag
+// keys that aren't kebab-case or are too long before they're used.
+//
+// Example:
+//
+//	ValuesMatch(t, "./feature", "feature.Flag", regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`))
+func ValuesMatch(t tHelper, pkg, typ string, re *regexp.Regexp) bool {
+	t.Helper()
+
+	return allMatch(t, pkg, typ, re, "values", enums.Enum.Unquoted)
+}
+
+// allMatch asserts that what is returned by field, called what, matches re
+// for every enum of typ in pkg.
+func allMatch(t tHelper, pkg, typ string, re *regexp.Regexp, what string, field func(enums.Enum) string) bool {
+	t.Helper()
+
+	collection, err := all(pkg, typ)
+	if err != nil {
+		t.Log("failed to load enums.All: " + err.Error())
+		t.Fail()
+		return false
+	}
+
+	var msg string
+	for _, e := range collection.Enums {
+		if !re.MatchString(field(e)) {
+			msg += fmt.Sprintf("\t%s = %s (declared at %s)\n", e.Name, e.Value, e.Pos)
+		}
+	}
+	if msg == "" {
+		return true
+	}
+
+	t.Log(fmt.Sprintf("Enums with %s not matching %s:\n%s", what, re, msg))
+	t.Fail()
+	return false
+}
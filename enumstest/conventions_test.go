@@ -0,0 +1,59 @@
+package enumstest_test
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums/enumstest"
+)
+
+func TestNamesMatch(t *testing.T) {
+	t.Run("passes when every name matches", func(t *testing.T) {
+		tl := new(tLogger)
+
+		require.True(t, enumstest.NamesMatch(tl, "../testdata/full", "full.Flag", regexp.MustCompile(`^Deploy`)))
+		require.Zero(t, tl.failCalled)
+		require.Empty(t, tl.log)
+	})
+
+	t.Run("fails with the names that don't match", func(t *testing.T) {
+		tl := new(tLogger)
+		file, err := filepath.Abs("../testdata/full/example.go")
+		require.NoError(t, err)
+
+		require.False(t, enumstest.NamesMatch(tl, "../testdata/full", "full.Flag", regexp.MustCompile(`Things$`)))
+		require.Equal(t, 1, tl.failCalled)
+		require.Equal(
+			t,
+			[]interface{}{[]interface{}{"Enums with names not matching Things$:\n\tDeployOneThing = \"deploy-one-thing\" (declared at " + file + ":7:2)\n"}},
+			tl.log,
+		)
+	})
+}
+
+func TestValuesMatch(t *testing.T) {
+	t.Run("passes when every unquoted value matches", func(t *testing.T) {
+		tl := new(tLogger)
+
+		require.True(t, enumstest.ValuesMatch(tl, "../testdata/full", "full.Flag", regexp.MustCompile(`^[a-z]+(-[a-z]+)*$`)))
+		require.Zero(t, tl.failCalled)
+		require.Empty(t, tl.log)
+	})
+
+	t.Run("fails with the values that don't match", func(t *testing.T) {
+		tl := new(tLogger)
+		file, err := filepath.Abs("../testdata/full/example.go")
+		require.NoError(t, err)
+
+		require.False(t, enumstest.ValuesMatch(tl, "../testdata/full", "full.Flag", regexp.MustCompile(`^.{1,16}$`)))
+		require.Equal(t, 1, tl.failCalled)
+		require.Equal(
+			t,
+			[]interface{}{[]interface{}{"Enums with values not matching ^.{1,16}$:\n\tDeployAllTheThings = \"deploy-all-the-things\" (declared at " + file + ":6:2)\n"}},
+			tl.log,
+		)
+	})
+}
@@ -0,0 +1,65 @@
+// Package enumsassert has the enumstest helpers for tests using testify, so
+// failures are reported with testify's formatting, with the failure message
+// and its arguments the same way as assert and require, and work inside
+// testify suites.
+package enumsassert
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums/enumstest"
+)
+
+type tHelper interface {
+	Helper()
+}
+
+// NoDiff asserts that the enums of typ in pkg have all the values from
+// actual like enumstest.NoDiff, and reports the diff with assert.Fail.
+//
+// Example:
+//
+//	enumsassert.NoDiff(t, "./feature", "feature.Flag", feature.AllFlags(), "flag %s is missing", name)
+func NoDiff(t assert.TestingT, pkg, typ string, actual interface{}, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	var r recorder
+	if enumstest.NoDiff(&r, pkg, typ, actual) {
+		return true
+	}
+
+	return assert.Fail(t, r.msg, msgAndArgs...)
+}
+
+// RequireNoDiff is NoDiff stopping the test on a diff, like require.
+//
+// Example:
+//
+//	enumsassert.RequireNoDiff(t, "./feature", "feature.Flag", feature.AllFlags())
+func RequireNoDiff(t require.TestingT, pkg, typ string, actual interface{}, msgAndArgs ...interface{}) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if !NoDiff(t, pkg, typ, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// recorder records the message of a failing enumstest helper instead of failing a test.
+type recorder struct {
+	msg string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Log(args ...interface{}) {
+	r.msg = fmt.Sprint(args...)
+}
+
+func (r *recorder) Fail() {}
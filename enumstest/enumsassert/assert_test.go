@@ -0,0 +1,62 @@
+package enumsassert_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums/enumstest/enumsassert"
+	"github.com/gaqzi/enums/testdata/full"
+)
+
+// testingT records the failures reported by testify.
+type testingT struct {
+	errors    []string
+	failedNow bool
+}
+
+func (t *testingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *testingT) FailNow() {
+	t.failedNow = true
+}
+
+func TestNoDiff(t *testing.T) {
+	t.Run("passes when there is no diff", func(t *testing.T) {
+		tt := new(testingT)
+
+		require.True(t, enumsassert.NoDiff(tt, "../../testdata/full", "full.Flag", full.AllFlags()))
+		require.Empty(t, tt.errors)
+	})
+
+	t.Run("reports the diff with testify's formatting", func(t *testing.T) {
+		tt := new(testingT)
+
+		require.False(t, enumsassert.NoDiff(tt, "../../testdata/full", "full.Flag", full.MissingFlags(), "flags for %s", "checkout"))
+		require.Len(t, tt.errors, 1)
+		require.Contains(t, tt.errors[0], "Error Trace:")
+		require.Contains(t, tt.errors[0], `DeployOneThing = "deploy-one-thing"`)
+		require.Contains(t, tt.errors[0], "Messages:   \tflags for checkout")
+		require.False(t, tt.failedNow)
+	})
+}
+
+func TestRequireNoDiff(t *testing.T) {
+	t.Run("passes when there is no diff", func(t *testing.T) {
+		tt := new(testingT)
+
+		enumsassert.RequireNoDiff(tt, "../../testdata/full", "full.Flag", full.AllFlags())
+		require.False(t, tt.failedNow)
+	})
+
+	t.Run("stops the test on a diff", func(t *testing.T) {
+		tt := new(testingT)
+
+		enumsassert.RequireNoDiff(tt, "../../testdata/full", "full.Flag", full.MissingFlags())
+		require.Len(t, tt.errors, 1)
+		require.True(t, tt.failedNow)
+	})
+}
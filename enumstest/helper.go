@@ -1,6 +1,9 @@
 package enumstest
 
 import (
+	"fmt"
+	"testing"
+
 	"github.com/gaqzi/enums"
 )
 
@@ -12,21 +15,100 @@ type tHelper interface {
 
 // NoDiff looks up all types in pkg and asserts they they have all the values from actual
 //
+// The types found are cached for the lifetime of the test binary, see DisableCache.
+//
 // Example:
-//   NoDiff(t, "./feature", "feature.Flag", []feature.Flag{"flag1", "flag2"})
+//
+//	NoDiff(t, "./feature", "feature.Flag", []feature.Flag{"flag1", "flag2"})
 func NoDiff(t tHelper, pkg, typ string, actual interface{}, failureMsg ...string) bool {
 	t.Helper()
 
-	collection, err := enums.All(pkg, typ)
+	msg, ok := check(pkg, typ, actual, failureMsg)
+	if ok {
+		return true
+	}
+
+	t.Log(msg)
+	t.Fail()
+	return false
+}
+
+// AssertNoDiff is NoDiff for a testing.TB, reporting the failure with
+// Errorf so the test keeps running, and returns whether there was no diff.
+//
+// Example:
+//
+//	AssertNoDiff(t, "./feature", "feature.Flag", []feature.Flag{"flag1", "flag2"})
+func AssertNoDiff(t testing.TB, pkg, typ string, actual interface{}, failureMsg ...string) bool {
+	t.Helper()
+
+	msg, ok := check(pkg, typ, actual, failureMsg)
+	if !ok {
+		t.Errorf("%s", msg)
+	}
+
+	return ok
+}
+
+// RequireNoDiff is NoDiff for a testing.TB, reporting the failure with
+// Fatalf so the test stops immediately.
+//
+// Example:
+//
+//	RequireNoDiff(t, "./feature", "feature.Flag", []feature.Flag{"flag1", "flag2"})
+func RequireNoDiff(t testing.TB, pkg, typ string, actual interface{}, failureMsg ...string) {
+	t.Helper()
+
+	if msg, ok := check(pkg, typ, actual, failureMsg); !ok {
+		t.Fatalf("%s", msg)
+	}
+}
+
+// NoDiffFor is NoDiff for the enums of the type T, found with enums.AllOf,
+// so there are no strings to keep in sync when the type is renamed or moved.
+//
+// Example:
+//
+//	NoDiffFor(t, []feature.Flag{"flag1", "flag2"})
+func NoDiffFor[T any](t tHelper, actual []T, failureMsg ...string) bool {
+	t.Helper()
+
+	collection, err := allOf[T]()
 	if err != nil {
-		t.Log("failed to load enums.All: " + err.Error())
+		t.Log("failed to load enums.AllOf: " + err.Error())
 		t.Fail()
 		return false
 	}
 
+	msg, ok := explain(collection, actual, failureMsg)
+	if ok {
+		return true
+	}
+
+	t.Log(msg)
+	t.Fail()
+	return false
+}
+
+// check diffs the enums of typ in pkg against actual and returns whether
+// there's no diff, and if there is, the message explaining it.
+//
+// The collection is read from the cache unless it's been disabled.
+func check(pkg, typ string, actual interface{}, failureMsg []string) (string, bool) {
+	collection, err := all(pkg, typ)
+	if err != nil {
+		return "failed to load enums.All: " + err.Error(), false
+	}
+
+	return explain(collection, actual, failureMsg)
+}
+
+// explain diffs collection against actual and returns whether there's no
+// diff, and if there is, the message explaining it.
+func explain(collection enums.Collection, actual interface{}, failureMsg []string) (string, bool) {
 	diff := collection.Diff(actual)
 	if diff.Zero() {
-		return true
+		return "", true
 	}
 
 	var msg string
@@ -34,7 +116,13 @@ func NoDiff(t tHelper, pkg, typ string, actual interface{}, failureMsg ...string
 		msg += failureMsg[0] + "\n"
 	}
 
-	t.Log(msg + diff.String())
-	t.Fail()
-	return false
+	msg += diff.String()
+	if len(collection.Diagnostics) > 0 {
+		msg += "Declarations skipped when scanning:\n"
+		for _, d := range collection.Diagnostics {
+			msg += fmt.Sprintf("\t%s\n", d)
+		}
+	}
+
+	return msg, false
 }
@@ -1,6 +1,7 @@
 package enumstest_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -67,7 +68,7 @@ func TestNoDiff(t *testing.T) {
 					[]interface{}{
 						"expected a missing difference\n" +
 							"Enums declared but not part of actual:\n" +
-							"\tDeployOneThing = \"deploy-one-thing\"\n",
+							"\tDeployOneThing = \"deploy-one-thing\" (declared at testdata/full/example.go:7)\n",
 					},
 				},
 			},
@@ -75,4 +76,120 @@ func TestNoDiff(t *testing.T) {
 			"expected to have called with a precise error and to have called fail",
 		)
 	})
+
+	t.Run("Explains skipped declarations when diffs are found", func(t *testing.T) {
+		tl := new(tLogger)
+
+		require.False(t, enumstest.NoDiff(
+			tl,
+			"../testdata/diagnostics",
+			"diagnostics.Flag",
+			[]string{},
+		))
+
+		require.Len(t, tl.log, 1)
+		msg := tl.log[0].([]interface{})[0].(string)
+		require.Contains(t, msg, "Declarations skipped when scanning:\n")
+		require.Contains(t, msg, "FlagComputed skipped: unsupported expression")
+	})
+}
+
+func TestNoDiffFor(t *testing.T) {
+	t.Run("passes when there is no diff", func(t *testing.T) {
+		tl := new(tLogger)
+
+		require.True(t, enumstest.NoDiffFor(tl, full.AllFlags()))
+		require.Equal(t, &tLogger{helperCalled: 1}, tl)
+	})
+
+	t.Run("fails with the diff", func(t *testing.T) {
+		tl := new(tLogger)
+
+		require.False(t, enumstest.NoDiffFor(tl, full.MissingFlags(), "expected a missing difference"))
+		require.Equal(t, 1, tl.failCalled)
+		require.Equal(
+			t,
+			[]interface{}{[]interface{}{
+				"expected a missing difference\n" +
+					"Enums declared but not part of actual:\n" +
+					"\tDeployOneThing = \"deploy-one-thing\" (declared at testdata/full/example.go:7)\n",
+			}},
+			tl.log,
+		)
+	})
+
+	t.Run("fails when the enums can't be found", func(t *testing.T) {
+		tl := new(tLogger)
+
+		require.False(t, enumstest.NoDiffFor(tl, []*full.Flag{}))
+		require.Equal(t, 1, tl.failCalled)
+		require.Equal(t, []interface{}{[]interface{}{"failed to load enums.AllOf: *full.Flag is not a named type declared in a package"}}, tl.log)
+	})
+}
+
+// tbRecorder is a testing.TB that records failures instead of failing the test.
+type tbRecorder struct {
+	testing.TB
+
+	errors []string
+	fatals []string
+}
+
+func (t *tbRecorder) Helper() {}
+
+func (t *tbRecorder) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *tbRecorder) Fatalf(format string, args ...interface{}) {
+	t.fatals = append(t.fatals, fmt.Sprintf(format, args...))
+}
+
+func TestAssertNoDiff(t *testing.T) {
+	t.Run("passes when there is no diff", func(t *testing.T) {
+		tb := new(tbRecorder)
+
+		require.True(t, enumstest.AssertNoDiff(tb, "../testdata/full", "full.Flag", full.AllFlags()))
+		require.Empty(t, tb.errors)
+	})
+
+	t.Run("reports the diff with Errorf", func(t *testing.T) {
+		tb := new(tbRecorder)
+
+		require.False(t, enumstest.AssertNoDiff(tb, "../testdata/full", "full.Flag", full.MissingFlags(), "expected a missing difference"))
+		require.Equal(
+			t,
+			[]string{
+				"expected a missing difference\n" +
+					"Enums declared but not part of actual:\n" +
+					"\tDeployOneThing = \"deploy-one-thing\" (declared at testdata/full/example.go:7)\n",
+			},
+			tb.errors,
+		)
+		require.Empty(t, tb.fatals)
+	})
+}
+
+func TestRequireNoDiff(t *testing.T) {
+	t.Run("passes when there is no diff", func(t *testing.T) {
+		tb := new(tbRecorder)
+
+		enumstest.RequireNoDiff(tb, "../testdata/full", "full.Flag", full.AllFlags())
+		require.Empty(t, tb.fatals)
+	})
+
+	t.Run("reports the diff with Fatalf", func(t *testing.T) {
+		tb := new(tbRecorder)
+
+		enumstest.RequireNoDiff(tb, "../testdata/full", "full.Flag", full.MissingFlags())
+		require.Equal(
+			t,
+			[]string{
+				"Enums declared but not part of actual:\n" +
+					"\tDeployOneThing = \"deploy-one-thing\" (declared at testdata/full/example.go:7)\n",
+			},
+			tb.fatals,
+		)
+		require.Empty(t, tb.errors)
+	})
 }
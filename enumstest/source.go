@@ -0,0 +1,58 @@
+package enumstest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gaqzi/enums"
+)
+
+// Scan writes the source files, keyed by file name, to a package in a
+// temporary module and finds the enums of typ in it, so scanning options
+// such as extractors and registrations can be tested without committing a
+// testdata package. The package is imported as the import path typ is
+// qualified with, or as example.com/ followed by the package name when typ
+// is qualified with only the name, and can only import the standard library.
+//
+// The packages are never cached and the test is stopped with Fatalf when
+// they can't be loaded.
+//
+// Example:
+//
+//	collection := Scan(t, map[string]string{
+//		"flag.go": `package feature
+//
+//	type Flag string
+//
+//	const FlagOn Flag = "on"`,
+//	}, "feature.Flag")
+func Scan(t testing.TB, files map[string]string, typ string, opts ...enums.Option) enums.Collection {
+	t.Helper()
+
+	dir := t.TempDir()
+	pkg := typ
+	if dot := strings.LastIndex(typ, "."); dot >= 0 {
+		pkg = typ[:dot]
+	}
+	if !strings.Contains(pkg, "/") {
+		pkg = "example.com/" + pkg
+	}
+	mod := "module " + pkg + "\n\ngo 1.21\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(mod), 0o600); err != nil {
+		t.Fatalf("failed to write go.mod: %s", err)
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %s", name, err)
+		}
+	}
+
+	collection, err := enums.All(".", typ, append([]enums.Option{enums.WithDir(dir)}, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to scan %s: %s", typ, err)
+	}
+
+	return collection
+}
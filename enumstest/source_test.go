@@ -0,0 +1,58 @@
+package enumstest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+	"github.com/gaqzi/enums/enumstest"
+)
+
+func TestScan(t *testing.T) {
+	t.Run("finds the enums in the source", func(t *testing.T) {
+		collection := enumstest.Scan(t, map[string]string{
+			"flag.go": `package feature
+
+type Flag string
+
+const (
+	FlagOn  Flag = "on"
+	FlagOff Flag = "off"
+)
+`,
+		}, "feature.Flag")
+
+		require.Equal(t, []string{"off", "on"}, collection.Values())
+		require.Equal(t, "example.com/feature", collection.Enums[0].Package)
+	})
+
+	t.Run("imports the package as the path the type is qualified with", func(t *testing.T) {
+		collection := enumstest.Scan(t, map[string]string{
+			"flag.go": `package feature
+
+type Flag string
+
+const FlagOn Flag = "on"
+`,
+		}, "github.com/acme/feature.Flag")
+
+		require.Equal(t, []string{"on"}, collection.Values())
+		require.Equal(t, "github.com/acme/feature", collection.Enums[0].Package)
+	})
+
+	t.Run("scans with the options", func(t *testing.T) {
+		collection := enumstest.Scan(t, map[string]string{
+			"flag.go": `package feature
+
+type Flag struct{ name string }
+
+func Register(name string) *Flag { return &Flag{name: name} }
+
+var FlagOn = Register("on")
+`,
+		}, "feature.Flag", enums.WithRegistration("feature.Register", 0))
+
+		require.Equal(t, []string{"on"}, collection.Values())
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gaqzi/enums"
 	"github.com/gaqzi/enums/enumstest"
 	"github.com/gaqzi/enums/testdata/full"
+	"github.com/gaqzi/enums/testdata/registry"
 )
 
 func TestIntegration(t *testing.T) {
@@ -48,19 +49,84 @@ func TestIntegration(t *testing.T) {
 		})
 
 		t.Run("when something is missing", func(t *testing.T) {
+			diff := fsCollection.Diff(full.MissingFlagStruct())
+			diff.Missing.Enums = withoutPos(diff.Missing.Enums)
+
 			require.Equal(
 				t,
 				enums.Diff{
 					Missing: enums.Collection{
 						Type:      "github.com/gaqzi/enums/testdata/full.FlagStruct",
 						FieldName: "Name",
+						Module:    mainModule(t),
 						Enums: []enums.Enum{
-							{"FlagDefaultOn", `"flag-default-on"`},
+							{
+								Name:    "FlagDefaultOn",
+								Value:   `"flag-default-on"`,
+								Type:    "github.com/gaqzi/enums/testdata/full.FlagStruct",
+								Package: "github.com/gaqzi/enums/testdata/full",
+								Fields:  map[string]string{"Name": `"flag-default-on"`, "DefaultOn": "true"},
+							},
 						},
 					},
 				},
-				fsCollection.Diff(full.MissingFlagStruct()),
+				diff,
+			)
+		})
+	})
+
+	t.Run("Handles identifiers on embedded structs", func(t *testing.T) {
+		efCollection, err := enums.All("./testdata/full", "full.EmbeddedFlag")
+		require.NoError(t, err)
+		require.Equal(t, "Name", efCollection.FieldName, "expected the promoted field to be the identifier")
+
+		t.Run("when all flags are present", func(t *testing.T) {
+			diff := efCollection.Diff(full.AllEmbeddedFlags())
+			require.Truef(t, diff.Zero(), "expected no differences: %s", diff)
+		})
+
+		t.Run("when something is missing", func(t *testing.T) {
+			diff := efCollection.Diff(full.MissingEmbeddedFlags())
+			require.Equal(
+				t,
+				[]enums.Enum{{
+					Name:    "FlagEmbeddedDefault",
+					Value:   `"flag-embedded-default"`,
+					Type:    "github.com/gaqzi/enums/testdata/full.EmbeddedFlag",
+					Package: "github.com/gaqzi/enums/testdata/full",
+					Fields:  map[string]string{"Name": `"flag-embedded-default"`, "DefaultOn": "true"},
+				}},
+				withoutPos(diff.Missing.Enums),
+			)
+			require.Empty(t, diff.Extra)
+		})
+	})
+
+	t.Run("Handles interfaces by the concrete types assigned to them", func(t *testing.T) {
+		encCollection, err := enums.All("./testdata/registry", "registry.Encoder")
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			[]enums.Enum{
+				{Name: "JSON", Value: "registry.jsonEncoder", Type: "github.com/gaqzi/enums/testdata/registry.Encoder", Package: "github.com/gaqzi/enums/testdata/registry"},
+				{Name: "XML", Value: "*registry.xmlEncoder", Type: "github.com/gaqzi/enums/testdata/registry.Encoder", Package: "github.com/gaqzi/enums/testdata/registry"},
+			},
+			withoutPos(encCollection.Enums),
+		)
+
+		t.Run("when all encoders are present", func(t *testing.T) {
+			diff := encCollection.Diff(registry.AllEncoders())
+			require.Truef(t, diff.Zero(), "expected no differences: %s", diff)
+		})
+
+		t.Run("when something is missing", func(t *testing.T) {
+			diff := encCollection.Diff(registry.MissingEncoders())
+			require.Equal(
+				t,
+				[]enums.Enum{{Name: "XML", Value: "*registry.xmlEncoder", Type: "github.com/gaqzi/enums/testdata/registry.Encoder", Package: "github.com/gaqzi/enums/testdata/registry"}},
+				withoutPos(diff.Missing.Enums),
 			)
+			require.Empty(t, diff.Extra)
 		})
 	})
 }
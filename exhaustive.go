@@ -0,0 +1,63 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// WriteExhaustiveConfig writes golangci-lint v2 settings for the exhaustive
+// linter (https://github.com/nishanths/exhaustive) that make it agree with
+// the collections on what the members of each enum are.
+//
+// exhaustive considers every constant of a type a member of the enum, so the
+// declarations a Collection skipped or left out by an //enums:ignore
+// directive, see Collection.Diagnostics and Collection.Ignored, are written
+// as ignored members. Struct based collections can't be checked by
+// exhaustive and are listed in a comment only.
+//
+// Example:
+//
+//	flags, _ := All("./feature", "feature.Flag")
+//	WriteExhaustiveConfig(os.Stdout, flags)
+func WriteExhaustiveConfig(w io.Writer, collections ...Collection) error {
+	var ignored, structs []string
+	for _, c := range collections {
+		if c.Type == "" {
+			continue // nothing was found so there's nothing to agree on
+		}
+
+		if c.FieldName != "" {
+			structs = append(structs, c.Type)
+			continue
+		}
+
+		pkgPath := c.Type[:strings.LastIndex(c.Type, ".")+1]
+		for _, d := range slices.Concat(c.Diagnostics, c.Ignored) {
+			ignored = append(ignored, regexp.QuoteMeta(pkgPath+d.Name))
+		}
+	}
+	sort.Strings(ignored)
+	sort.Strings(structs)
+
+	msg := "# Generated by enums, do not edit.\n" +
+		"version: \"2\"\n" +
+		"linters:\n" +
+		"  settings:\n" +
+		"    exhaustive:\n" +
+		"      check:\n" +
+		"        - switch\n" +
+		"        - map\n"
+	if len(ignored) > 0 {
+		msg += fmt.Sprintf("      ignore-enum-members: '^(%s)$'\n", strings.Join(ignored, "|"))
+	}
+	for _, s := range structs {
+		msg += fmt.Sprintf("# %s is a struct enum and can't be checked by exhaustive\n", s)
+	}
+
+	_, err := io.WriteString(w, msg)
+	return err
+}
@@ -0,0 +1,69 @@
+package enums_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestWriteExhaustiveConfig(t *testing.T) {
+	t.Run("checks switches and maps when nothing is skipped", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteExhaustiveConfig(&buf, enums.Collection{Type: "github.com/acme/feature.Flag"}))
+
+		require.Equal(
+			t,
+			"# Generated by enums, do not edit.\n"+
+				"version: \"2\"\n"+
+				"linters:\n"+
+				"  settings:\n"+
+				"    exhaustive:\n"+
+				"      check:\n"+
+				"        - switch\n"+
+				"        - map\n",
+			buf.String(),
+		)
+	})
+
+	t.Run("ignores skipped members and lists struct enums", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteExhaustiveConfig(
+			&buf,
+			enums.Collection{
+				Type:        "github.com/acme/feature.Flag",
+				Diagnostics: []enums.Diagnostic{{Name: "flagTestOnly"}, {Name: "FlagComputed"}},
+			},
+			enums.Collection{Type: "github.com/acme/feature.FlagStruct", FieldName: "Name"},
+		))
+
+		require.Equal(
+			t,
+			"# Generated by enums, do not edit.\n"+
+				"version: \"2\"\n"+
+				"linters:\n"+
+				"  settings:\n"+
+				"    exhaustive:\n"+
+				"      check:\n"+
+				"        - switch\n"+
+				"        - map\n"+
+				`      ignore-enum-members: '^(github\.com/acme/feature\.FlagComputed|github\.com/acme/feature\.flagTestOnly)$'`+"\n"+
+				"# github.com/acme/feature.FlagStruct is a struct enum and can't be checked by exhaustive\n",
+			buf.String(),
+		)
+	})
+
+	t.Run("ignores members left out by the ignore directive", func(t *testing.T) {
+		flags, err := enums.All("./testdata/ignore", "ignore.Flag")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteExhaustiveConfig(&buf, flags))
+
+		require.Contains(t, buf.String(), `ignore-enum-members: '^(github\.com/gaqzi/enums/testdata/ignore\.FlagDeprecated|github\.com/gaqzi/enums/testdata/ignore\.flagInternal|github\.com/gaqzi/enums/testdata/ignore\.flagTestOnly)$'`)
+	})
+}
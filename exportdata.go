@@ -0,0 +1,52 @@
+package enums
+
+import (
+	"go/constant"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ExportDataLoadMode is the packages.LoadMode packages passed to FromPackages
+// with WithExportData need to have been loaded with at least.
+const ExportDataLoadMode = packages.NeedName | packages.NeedTypes | packages.NeedModule
+
+// collectExportData adds the constants of typ in the package scope of p to
+// collection and returns whether the type itself is declared in p.
+func collectExportData(collection *Collection, p *packages.Package, typ string) (typeFound bool) {
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !matchesType(obj.Type(), typ) {
+			continue
+		}
+
+		switch obj := obj.(type) {
+		case *types.TypeName:
+			typeFound = true
+		case *types.Const:
+			// The constant has already been evaluated so iota and expressions work as well
+			collection.add(p, obj, "", Enum{Value: constValue(obj.Val())})
+		case *types.Var:
+			collection.Diagnostics = append(collection.Diagnostics, Diagnostic{
+				Name:   obj.Name(),
+				Pos:    p.Fset.Position(obj.Pos()),
+				Reason: "variables don't have their values in export data",
+			})
+		}
+	}
+
+	return typeFound
+}
+
+// constValue formats the value of a constant, floats are formatted like the
+// fmt package does as their exact value is a fraction such as 3/2.
+func constValue(v constant.Value) string {
+	if v.Kind() == constant.Float {
+		f, _ := constant.Float64Val(v)
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+
+	return v.ExactString()
+}
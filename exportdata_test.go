@@ -0,0 +1,62 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestAll_WithExportData(t *testing.T) {
+	t.Run("finds the same constants as when scanning the source", func(t *testing.T) {
+		expected, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+
+		matches, err := enums.All("./testdata/full", "full.Flag", enums.WithExportData())
+		require.NoError(t, err)
+
+		// Export data only has the line a constant is declared on
+		for i, e := range matches.Enums {
+			require.Equal(t, expected.Enums[i].Pos.Line, e.Pos.Line)
+		}
+		expected.Enums, matches.Enums = withoutPos(expected.Enums), withoutPos(matches.Enums)
+		require.Equal(t, expected, matches)
+	})
+
+	t.Run("has the values of constants declared with iota", func(t *testing.T) {
+		matches, err := enums.All("./testdata/diagnostics", "diagnostics.Stage", enums.WithExportData())
+		require.NoError(t, err)
+
+		require.Equal(
+			t,
+			[]enums.Enum{
+				{Name: "StageOne", Value: "0", Type: "github.com/gaqzi/enums/testdata/diagnostics.Stage", Package: "github.com/gaqzi/enums/testdata/diagnostics"},
+				{Name: "StageTwo", Value: "1", Type: "github.com/gaqzi/enums/testdata/diagnostics.Stage", Package: "github.com/gaqzi/enums/testdata/diagnostics"},
+			},
+			withoutPos(matches.Enums),
+		)
+		require.Empty(t, matches.Diagnostics)
+	})
+
+	t.Run("explains that variables can't be read", func(t *testing.T) {
+		matches, err := enums.All("./testdata/full", "full.FlagStruct", enums.WithExportData())
+		require.NoError(t, err)
+
+		require.Empty(t, matches.Enums)
+		require.Len(t, matches.Diagnostics, 1)
+		require.Equal(t, "FlagDefaultOn", matches.Diagnostics[0].Name)
+		require.Equal(t, "variables don't have their values in export data", matches.Diagnostics[0].Reason)
+	})
+
+	t.Run("FromPackages accepts packages loaded with ExportDataLoadMode", func(t *testing.T) {
+		pkgs, err := packages.Load(&packages.Config{Mode: enums.ExportDataLoadMode}, "./testdata/full")
+		require.NoError(t, err)
+
+		matches, err := enums.FromPackages(pkgs, "full.Flag", enums.WithExportData())
+		require.NoError(t, err)
+
+		require.Len(t, matches.Enums, 2)
+	})
+}
@@ -0,0 +1,89 @@
+package enums
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"sync"
+)
+
+// Extractor returns the value of an expression assigned to a declaration of
+// the type, and whether it knows how to, for idioms the scanner doesn't
+// support out of the box. The value is formatted like Go source, so strings
+// are quoted.
+type Extractor func(expr ast.Expr, info *types.Info) (value string, ok bool)
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   []*Extractor
+	// extractorsGen changes with every extractor registered or removed, so
+	// scans with different extractors aren't deduplicated.
+	extractorsGen uint64
+)
+
+// RegisterExtractor adds an extractor that's tried, in the order they were
+// registered, for every value the scanner doesn't support itself. It's
+// meant to be called from an init function, before scanning. It returns a
+// function removing the extractor again, for tests.
+//
+// Scans aren't read from or written to WithCache while any extractor is
+// registered, as there's no telling what an extractor returns from one run
+// to the next.
+//
+// Example:
+//
+//	func init() {
+//		enums.RegisterExtractor(func(expr ast.Expr, info *types.Info) (string, bool) {
+//			call, ok := expr.(*ast.CallExpr)
+//			if !ok || types.ExprString(call.Fun) != "flags.Lookup" {
+//				return "", false
+//			}
+//
+//			if lit, ok := call.Args[0].(*ast.BasicLit); ok {
+//				return lit.Value, true
+//			}
+//
+//			return "", false
+//		})
+//	}
+func RegisterExtractor(fn Extractor) (unregister func()) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+
+	registered := &fn
+	extractors = append(extractors, registered)
+	extractorsGen++
+
+	return func() {
+		extractorsMu.Lock()
+		defer extractorsMu.Unlock()
+
+		if i := slices.Index(extractors, registered); i >= 0 {
+			extractors = slices.Delete(extractors, i, i+1)
+			extractorsGen++
+		}
+	}
+}
+
+// registeredExtractors returns the generation of the registered extractors
+// and how many there are.
+func registeredExtractors() (gen uint64, n int) {
+	extractorsMu.RLock()
+	defer extractorsMu.RUnlock()
+
+	return extractorsGen, len(extractors)
+}
+
+// extract returns the value of expr from the first registered extractor that knows how to.
+func extract(expr ast.Expr, info *types.Info) (string, bool) {
+	extractorsMu.RLock()
+	defer extractorsMu.RUnlock()
+
+	for _, fn := range extractors {
+		if v, ok := (*fn)(expr, info); ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,61 @@
+package enums_test
+
+import (
+	"go/ast"
+	"go/types"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestRegisterExtractor(t *testing.T) {
+	lookup := func(expr ast.Expr, info *types.Info) (string, bool) {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok || types.ExprString(call.Fun) != "lookup" {
+			return "", false
+		}
+
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok {
+			return "", false
+		}
+
+		return lit.Value, true
+	}
+
+	t.Run("extracts the values the extractor knows", func(t *testing.T) {
+		t.Cleanup(enums.RegisterExtractor(lookup))
+
+		matches, err := enums.All("./testdata/extractor", "extractor.Flag")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"flag-a"}, matches.Values())
+		require.Len(t, matches.Diagnostics, 1)
+		require.Equal(t, "FlagB", matches.Diagnostics[0].Name, "expected the value the extractor doesn't know to be skipped")
+	})
+
+	t.Run("doesn't cache scans with extractors", func(t *testing.T) {
+		t.Cleanup(enums.RegisterExtractor(lookup))
+		dir := t.TempDir()
+
+		_, err := enums.All("./testdata/extractor", "extractor.Flag", enums.WithCache(enums.FileCache{Dir: dir}))
+		require.NoError(t, err)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("stops extracting once unregistered", func(t *testing.T) {
+		enums.RegisterExtractor(lookup)()
+
+		matches, err := enums.All("./testdata/extractor", "extractor.Flag")
+		require.NoError(t, err)
+
+		require.Empty(t, matches.Values())
+		require.Len(t, matches.Diagnostics, 2)
+	})
+}
@@ -0,0 +1,157 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"path"
+	"strings"
+)
+
+// WriteSwitch writes a switch statement over the variable v with one case
+// per enum in the collection and a default returning an error, ready to be
+// filled in when writing a new handler for the enum.
+//
+// Example:
+//
+//	flags, _ := All("./feature", "feature.Flag")
+//	WriteSwitch(os.Stdout, flags, "flag")
+func WriteSwitch(w io.Writer, c Collection, v string) error {
+	subject := v
+	if c.FieldName != "" {
+		subject = v + "." + c.FieldName
+	}
+
+	var src strings.Builder
+	fmt.Fprintf(&src, "switch %s {\n", subject)
+	for _, e := range c.Enums {
+		if c.FieldName != "" {
+			fmt.Fprintf(&src, "case %s.%s:\n", e.Name, c.FieldName)
+		} else {
+			fmt.Fprintf(&src, "case %s:\n", e.Name)
+		}
+		fmt.Fprintf(&src, "\t// TODO: handle %s\n", e.Name)
+	}
+	fmt.Fprintf(&src, "default:\nreturn fmt.Errorf(\"unknown %s: %%v\", %s)\n}\n", shortType(c.Type), subject)
+
+	return writeSource(w, src.String())
+}
+
+// WriteTestSkeleton writes a table driven test file for the package of the
+// collection, with an entry and a TODO per enum, so a new value shows up as a
+// gap in the test table that needs to be filled in.
+//
+// Example:
+//
+//	flags, _ := All("./feature", "feature.Flag")
+//	WriteTestSkeleton(os.Stdout, flags)
+func WriteTestSkeleton(w io.Writer, c Collection) error {
+	if c.Type == "" {
+		return errors.New("collection has no type, nothing to generate a test for")
+	}
+
+	dot := strings.LastIndex(c.Type, ".")
+	importPath, name := c.Type[:dot], c.Type[dot+1:]
+	pkgName := path.Base(importPath)
+
+	var src strings.Builder
+	fmt.Fprintf(&src, "package %s_test\n\n", pkgName)
+	fmt.Fprintf(&src, "import (\n\"testing\"\n\n%q\n)\n\n", importPath)
+	fmt.Fprintf(&src, "func Test%s(t *testing.T) {\n", name)
+	fmt.Fprintf(&src, "testCases := []struct {\nname string\nvalue %s.%s\n}{\n", pkgName, name)
+	for _, e := range c.Enums {
+		fmt.Fprintf(&src, "{name: %q, value: %s.%s}, // TODO: add the expected result\n", e.Name, pkgName, e.Name)
+	}
+	src.WriteString("}\n\n")
+	src.WriteString("for _, tc := range testCases {\nt.Run(tc.name, func(t *testing.T) {\n")
+	src.WriteString("t.Fatalf(\"TODO: assert the behavior of %v\", tc.value)\n")
+	src.WriteString("})\n}\n}\n")
+
+	return writeSource(w, src.String())
+}
+
+// WriteSlice writes a slice literal with every enum in the collection,
+// qualified with the package name so it can be pasted outside the package.
+//
+// Example:
+//
+//	flags, _ := All("./feature", "feature.Flag")
+//	WriteSlice(os.Stdout, flags)
+func WriteSlice(w io.Writer, c Collection) error {
+	if c.Type == "" {
+		return errors.New("collection has no type, nothing to generate a slice of")
+	}
+
+	typ := shortType(c.Type)
+	pkgName := typ[:strings.LastIndex(typ, ".")]
+
+	var src strings.Builder
+	fmt.Fprintf(&src, "[]%s{\n", typ)
+	for _, e := range c.Enums {
+		fmt.Fprintf(&src, "%s.%s,\n", pkgName, e.Name)
+	}
+	src.WriteString("}\n")
+
+	return writeSource(w, src.String())
+}
+
+// WriteSet writes a file for the package of the collection with a set type
+// of the enum backed by a map, named after the type like FlagSet, with Has,
+// Add, and All methods and an AllFlagSet variable holding every enum.
+//
+// Example:
+//
+//	flags, _ := All("./feature", "feature.Flag")
+//	WriteSet(os.Stdout, flags)
+func WriteSet(w io.Writer, c Collection) error {
+	if c.Type == "" {
+		return errors.New("collection has no type, nothing to generate a set of")
+	}
+	if c.FieldName != "" || c.Interface {
+		return fmt.Errorf("%s isn't ordered and can't be listed in order by a set", c.Type)
+	}
+
+	dot := strings.LastIndex(c.Type, ".")
+	pkgName, name := path.Base(c.Type[:dot]), c.Type[dot+1:]
+	set := name + "Set"
+
+	var src strings.Builder
+	src.WriteString("// Code generated by enums; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", pkgName)
+	src.WriteString("import (\n\"maps\"\n\"slices\"\n)\n\n")
+	fmt.Fprintf(&src, "// %s is a set of %s.\n", set, name)
+	fmt.Fprintf(&src, "type %s map[%s]struct{}\n\n", set, name)
+	fmt.Fprintf(&src, "// All%s has every declared %s.\n", set, name)
+	fmt.Fprintf(&src, "var All%s = New%s(\n", set, set)
+	for _, e := range c.Enums {
+		fmt.Fprintf(&src, "%s,\n", e.Name)
+	}
+	src.WriteString(")\n\n")
+	fmt.Fprintf(&src, "// New%s returns a set with the values.\n", set)
+	fmt.Fprintf(&src, "func New%s(values ...%s) %s {\ns := make(%s, len(values))\nfor _, v := range values {\ns.Add(v)\n}\n\nreturn s\n}\n\n", set, name, set, set)
+	src.WriteString("// Has returns whether v is in the set.\n")
+	fmt.Fprintf(&src, "func (s %s) Has(v %s) bool {\n_, ok := s[v]\nreturn ok\n}\n\n", set, name)
+	src.WriteString("// Add adds v to the set.\n")
+	fmt.Fprintf(&src, "func (s %s) Add(v %s) {\ns[v] = struct{}{}\n}\n\n", set, name)
+	src.WriteString("// All returns the values in the set in order.\n")
+	fmt.Fprintf(&src, "func (s %s) All() []%s {\nreturn slices.Sorted(maps.Keys(s))\n}\n", set, name)
+
+	return writeSource(w, src.String())
+}
+
+// shortType returns typ qualified with only the package name, like it's written in code.
+func shortType(typ string) string {
+	return path.Base(typ)
+}
+
+// writeSource formats the Go source and writes it to w.
+func writeSource(w io.Writer, src string) error {
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	_, err = w.Write(formatted)
+	return err
+}
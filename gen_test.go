@@ -0,0 +1,157 @@
+package enums_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestWriteSwitch(t *testing.T) {
+	t.Run("has a case per enum and a default returning an error", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteSwitch(&buf, collection, "flag"))
+
+		require.Equal(
+			t,
+			"switch flag {\n"+
+				"case DeployAllTheThings:\n"+
+				"\t// TODO: handle DeployAllTheThings\n"+
+				"case DeployOneThing:\n"+
+				"\t// TODO: handle DeployOneThing\n"+
+				"default:\n"+
+				"\treturn fmt.Errorf(\"unknown full.Flag: %v\", flag)\n"+
+				"}\n",
+			buf.String(),
+		)
+	})
+
+	t.Run("switches on the identifier field for structs", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.FlagStruct")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteSwitch(&buf, collection, "flag"))
+
+		require.Equal(
+			t,
+			"switch flag.Name {\n"+
+				"case FlagDefaultOn.Name:\n"+
+				"\t// TODO: handle FlagDefaultOn\n"+
+				"default:\n"+
+				"\treturn fmt.Errorf(\"unknown full.FlagStruct: %v\", flag.Name)\n"+
+				"}\n",
+			buf.String(),
+		)
+	})
+}
+
+func TestWriteSlice(t *testing.T) {
+	t.Run("has every enum qualified with the package", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteSlice(&buf, collection))
+
+		require.Equal(
+			t,
+			"[]full.Flag{\n"+
+				"\tfull.DeployAllTheThings,\n"+
+				"\tfull.DeployOneThing,\n"+
+				"}\n",
+			buf.String(),
+		)
+	})
+
+	t.Run("fails without a type", func(t *testing.T) {
+		require.EqualError(t, enums.WriteSlice(&bytes.Buffer{}, enums.Collection{}), "collection has no type, nothing to generate a slice of")
+	})
+}
+
+func TestWriteSet(t *testing.T) {
+	t.Run("has a set type and a set of every enum", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteSet(&buf, collection))
+
+		src := buf.String()
+		require.Contains(t, src, "// Code generated by enums; DO NOT EDIT.\n\npackage full\n")
+		require.Contains(t, src, "type FlagSet map[Flag]struct{}\n")
+		require.Contains(t, src, "var AllFlagSet = NewFlagSet(\n\tDeployAllTheThings,\n\tDeployOneThing,\n)\n")
+		for _, method := range []string{"func (s FlagSet) Has(v Flag) bool {", "func (s FlagSet) Add(v Flag) {", "func (s FlagSet) All() []Flag {"} {
+			require.Contains(t, src, method)
+		}
+	})
+
+	t.Run("names the set after the type when it ends in s", func(t *testing.T) {
+		collection, err := enums.All("./orders", "orders.Status", enums.WithDir("./testdata/workspace"))
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteSet(&buf, collection))
+
+		src := buf.String()
+		require.Contains(t, src, "type StatusSet map[Status]struct{}\n")
+		require.Contains(t, src, "var AllStatusSet = NewStatusSet(\n")
+	})
+
+	t.Run("fails for struct enums", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.FlagStruct")
+		require.NoError(t, err)
+
+		require.EqualError(t, enums.WriteSet(&bytes.Buffer{}, collection), "github.com/gaqzi/enums/testdata/full.FlagStruct isn't ordered and can't be listed in order by a set")
+	})
+}
+
+func TestWriteTestSkeleton(t *testing.T) {
+	t.Run("has an entry per enum", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteTestSkeleton(&buf, collection))
+
+		require.Equal(
+			t,
+			`package full_test
+
+import (
+	"testing"
+
+	"github.com/gaqzi/enums/testdata/full"
+)
+
+func TestFlag(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value full.Flag
+	}{
+		{name: "DeployAllTheThings", value: full.DeployAllTheThings}, // TODO: add the expected result
+		{name: "DeployOneThing", value: full.DeployOneThing},         // TODO: add the expected result
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Fatalf("TODO: assert the behavior of %v", tc.value)
+		})
+	}
+}
+`,
+			buf.String(),
+		)
+	})
+
+	t.Run("fails when the collection has no type", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		require.Error(t, enums.WriteTestSkeleton(&buf, enums.Collection{}))
+	})
+}
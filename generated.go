@@ -0,0 +1,125 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// StaleGeneratedError is returned by CheckGenerated when the code generated
+// for a type wasn't generated from its current enums.
+type StaleGeneratedError struct {
+	Type    string   // the type the code was generated for
+	Files   []string // the generated files using the type
+	Missing []string // the names of the enums the generated files don't use
+	Errors  []string // the type errors in the generated files, such as enums that no longer exist
+}
+
+func (e *StaleGeneratedError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "generated code for %s is stale, run go generate", e.Type)
+	for _, name := range e.Missing {
+		fmt.Fprintf(&b, "\n\tmissing %s", name)
+	}
+	for _, err := range e.Errors {
+		fmt.Fprintf(&b, "\n\t%s", err)
+	}
+
+	return b.String()
+}
+
+// CheckGenerated checks that the generated files of pkg using typ, those
+// with a "Code generated ... DO NOT EDIT." comment such as the output of
+// stringer or a generated AllFlags function, were generated from the current
+// enums of typ. It returns a *StaleGeneratedError when an enum isn't used by
+// any of them or they have type errors, such as using an enum that was
+// removed or whose value changed, and nil when they're up to date.
+//
+// Example:
+//
+//	func TestGenerated(t *testing.T) {
+//		require.NoError(t, enums.CheckGenerated("./feature", "feature.Level"))
+//	}
+func CheckGenerated(pkg, typ string, opts ...Option) error {
+	c := newConfig(opts)
+	if c.exportData {
+		return errors.New("checking generated code needs its source, it can't be done from export data")
+	}
+	c.objects = true
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, err := load(ctx, c, pkg)
+	if err != nil {
+		return err
+	}
+	collection, err := collect(ctx, c, pkgs, typ)
+	if err != nil {
+		return err
+	}
+
+	declared := make(map[types.Object]string, len(collection.Enums))
+	for _, e := range collection.Enums {
+		declared[e.Object] = e.Name
+	}
+
+	stale := &StaleGeneratedError{Type: typ}
+	used := make(map[string]bool)
+	for _, p := range pkgs {
+		for _, f := range p.Syntax {
+			if !ast.IsGenerated(f) {
+				continue
+			}
+
+			var names []string
+			var usesType bool
+			ast.Inspect(f, func(n ast.Node) bool {
+				ident, ok := n.(*ast.Ident)
+				if !ok {
+					return true
+				}
+
+				switch obj := p.TypesInfo.Uses[ident].(type) {
+				case *types.TypeName:
+					usesType = usesType || matchesType(obj.Type(), typ)
+				case *types.Const, *types.Var:
+					if name, ok := declared[obj]; ok {
+						names = append(names, name)
+					}
+				}
+
+				return true
+			})
+			if !usesType && len(names) == 0 {
+				continue // generated for something else, such as protobuf messages
+			}
+
+			file := p.Fset.Position(f.Pos()).Filename
+			stale.Files = append(stale.Files, file)
+			for _, name := range names {
+				used[name] = true
+			}
+			for _, err := range p.TypeErrors {
+				if err.Fset.Position(err.Pos).Filename == file {
+					stale.Errors = append(stale.Errors, err.Error())
+				}
+			}
+		}
+	}
+	if len(stale.Files) == 0 {
+		return fmt.Errorf("no generated code found for %s", typ)
+	}
+
+	for _, e := range collection.Enums {
+		if !used[e.Name] {
+			stale.Missing = append(stale.Missing, e.Name)
+		}
+	}
+	if len(stale.Missing) == 0 && len(stale.Errors) == 0 {
+		return nil
+	}
+
+	return stale
+}
@@ -0,0 +1,40 @@
+package enums_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestCheckGenerated(t *testing.T) {
+	t.Run("passes when the generated code uses every enum", func(t *testing.T) {
+		require.NoError(t, enums.CheckGenerated("./testdata/generated", "generated.Flag"))
+	})
+
+	t.Run("fails with the missing enums and type errors when stale", func(t *testing.T) {
+		err := enums.CheckGenerated("./testdata/generated/stale", "stale.Level")
+
+		var stale *enums.StaleGeneratedError
+		require.ErrorAs(t, err, &stale)
+		file, _ := filepath.Abs("testdata/generated/stale/level_string.go")
+		require.Equal(t, []string{file}, stale.Files)
+		require.Equal(t, []string{"LevelWarn"}, stale.Missing)
+		require.Equal(t, []string{file + ":11:8: undefined: LevelTrace"}, stale.Errors)
+		require.Equal(
+			t,
+			"generated code for stale.Level is stale, run go generate\n"+
+				"\tmissing LevelWarn\n"+
+				"\t"+file+":11:8: undefined: LevelTrace",
+			err.Error(),
+		)
+	})
+
+	t.Run("returns an error when no code was generated for the type", func(t *testing.T) {
+		err := enums.CheckGenerated("./testdata/full", "full.Flag")
+
+		require.EqualError(t, err, "no generated code found for full.Flag")
+	})
+}
@@ -1,8 +1,18 @@
 module github.com/gaqzi/enums
 
-go 1.16
+go 1.25.0
 
 require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/google/go-cmp v0.6.0
 	github.com/stretchr/testify v1.8.1
-	golang.org/x/tools v0.3.0
+	golang.org/x/sync v0.21.0
+	golang.org/x/tools v0.47.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.37.0 // indirect
 )
@@ -0,0 +1,122 @@
+package enums
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// MissingTranslations returns the enums of c without an entry in the
+// translations file, the message key of an enum being prefix followed by its
+// unquoted value, such as flags.dark-mode for the prefix "flags.".
+//
+// The file is read as JSON or TOML by its extension, and nested objects or
+// tables are keyed by their path joined with dots like in most i18n
+// libraries, so {"flags": {"dark-mode": "Dark mode"}} has the key
+// flags.dark-mode. An object or table with the fields of a go-i18n message,
+// such as the plural forms one and other, is a message keyed by its path.
+//
+// Example:
+//
+//	missing, _ := MissingTranslations(flags, "locales/en.json", "flags.")
+func MissingTranslations(c Collection, file, prefix string) (Collection, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return Collection{}, fmt.Errorf("failed to read translations: %w", err)
+	}
+
+	var keys map[string]bool
+	switch ext := filepath.Ext(file); ext {
+	case ".json":
+		keys, err = jsonKeys(b)
+	case ".toml":
+		keys, err = tomlKeys(b)
+	default:
+		return Collection{}, fmt.Errorf("unsupported translations file %s, only .json and .toml are supported", file)
+	}
+	if err != nil {
+		return Collection{}, fmt.Errorf("failed to parse translations %s: %w", file, err)
+	}
+
+	missing := Collection{Type: c.Type, FieldName: c.FieldName, Module: c.Module, Interface: c.Interface}
+	for _, e := range c.Enums {
+		if !keys[prefix+e.Unquoted()] {
+			missing.Enums = append(missing.Enums, e)
+		}
+	}
+
+	return missing, nil
+}
+
+// jsonKeys returns the message IDs of the JSON object b.
+func jsonKeys(b []byte) (map[string]bool, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	return messageKeys(doc), nil
+}
+
+// tomlKeys returns the message IDs of the TOML document b.
+func tomlKeys(b []byte) (map[string]bool, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	return messageKeys(doc), nil
+}
+
+// messageFields are the keys of a message written as an object or table in
+// go-i18n, such as {"id": {"one": "1 flag", "other": "{{.Count}} flags"}}
+// for a message with plural forms.
+var messageFields = map[string]bool{
+	"id": true, "description": true, "hash": true, "leftdelim": true, "rightdelim": true, "translation": true,
+	"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true,
+}
+
+// messageKeys returns the paths of every message in doc, where an object
+// with only message fields and an other or translation is a message of its
+// own, like go-i18n reads it, instead of a namespace of messages.
+func messageKeys(doc map[string]interface{}) map[string]bool {
+	keys := make(map[string]bool)
+	var walk func(prefix string, obj map[string]interface{})
+	walk = func(prefix string, obj map[string]interface{}) {
+		for k, v := range obj {
+			nested, ok := v.(map[string]interface{})
+			if !ok || isMessage(nested) {
+				keys[prefix+k] = true
+				continue
+			}
+
+			walk(prefix+k+".", nested)
+		}
+	}
+	walk("", doc)
+
+	return keys
+}
+
+// isMessage returns whether obj is a message with its fields, rather than
+// a namespace of messages that happen to be named like the fields, such as
+// {"male": "Male", "other": "Other"}.
+func isMessage(obj map[string]interface{}) bool {
+	for k := range obj {
+		if !messageFields[strings.ToLower(k)] {
+			return false
+		}
+	}
+
+	for k, v := range obj {
+		if _, ok := v.(string); ok && (strings.EqualFold(k, "other") || strings.EqualFold(k, "translation")) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestMissingTranslations(t *testing.T) {
+	flags, err := enums.All("./testdata/full", "full.Flag")
+	require.NoError(t, err)
+
+	t.Run("reports enums without a key in a JSON file", func(t *testing.T) {
+		missing, err := enums.MissingTranslations(flags, "testdata/i18n/en.json", "flags.")
+		require.NoError(t, err)
+
+		require.Equal(t, flags.Type, missing.Type)
+		require.Len(t, missing.Enums, 1)
+		require.Equal(t, "DeployOneThing", missing.Enums[0].Name)
+	})
+
+	t.Run("reads the keys of tables in a TOML file", func(t *testing.T) {
+		missing, err := enums.MissingTranslations(flags, "testdata/i18n/sv.toml", "flags.")
+		require.NoError(t, err)
+
+		require.Len(t, missing.Enums, 1, "expected keys inside multi-line strings, arrays, and inline tables to not count")
+		require.Equal(t, "DeployOneThing", missing.Enums[0].Name)
+	})
+
+	t.Run("reads go-i18n messages with plural forms as a single key", func(t *testing.T) {
+		for _, file := range []string{"testdata/i18n/plural/en.json", "testdata/i18n/plural/sv.toml"} {
+			missing, err := enums.MissingTranslations(flags, file, "flags.")
+			require.NoError(t, err)
+
+			require.Empty(t, missing.Enums, file)
+		}
+	})
+
+	t.Run("reads objects with other keys than message fields as namespaces", func(t *testing.T) {
+		genders, err := enums.All("./testdata/gender", "gender.Gender")
+		require.NoError(t, err)
+
+		missing, err := enums.MissingTranslations(genders, "testdata/i18n/gender.json", "gender.")
+		require.NoError(t, err)
+
+		require.Empty(t, missing.Enums, "expected other to be a translation, not a plural form")
+	})
+
+	t.Run("reports every enum when the prefix doesn't match", func(t *testing.T) {
+		missing, err := enums.MissingTranslations(flags, "testdata/i18n/en.json", "features.")
+		require.NoError(t, err)
+
+		require.Len(t, missing.Enums, 2)
+	})
+
+	t.Run("fails for unsupported files", func(t *testing.T) {
+		_, err := enums.MissingTranslations(flags, "testdata/openapi/spec.yaml", "flags.")
+
+		require.EqualError(t, err, "unsupported translations file testdata/openapi/spec.yaml, only .json and .toml are supported")
+	})
+}
@@ -0,0 +1,84 @@
+// Package goproxy publishes modules from a directory in the layout of a
+// module proxy, for testing what is read from GOPROXY=file:// URLs.
+package goproxy
+
+import (
+	"archive/zip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Publish publishes the module in dir as module@version in a directory laid
+// out like a module proxy, and returns the directory for GOPROXY=file://.
+func Publish(t testing.TB, dir, module, version string) string {
+	t.Helper()
+
+	proxy := t.TempDir()
+	versions := filepath.Join(proxy, module, "@v")
+	if err := os.MkdirAll(versions, 0o755); err != nil {
+		t.Fatalf("failed to create the proxy: %s", err)
+	}
+
+	mod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		t.Fatalf("failed to read go.mod: %s", err)
+	}
+	files := map[string][]byte{
+		"list":            []byte(version + "\n"),
+		version + ".info": []byte(`{"Version":"` + version + `","Time":"2024-01-01T00:00:00Z"}`),
+		version + ".mod":  mod,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(versions, name), data, 0o644); err != nil {
+			t.Fatalf("failed to write %s: %s", name, err)
+		}
+	}
+
+	if err := writeZip(filepath.Join(versions, version+".zip"), dir, module+"@"+version+"/"); err != nil {
+		t.Fatalf("failed to zip %s: %s", dir, err)
+	}
+
+	return proxy
+}
+
+// writeZip writes the files in dir to a zip file with their paths prefixed.
+func writeZip(file, dir, prefix string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		zf, err := w.Create(prefix + filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		_, err = zf.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,119 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"strings"
+)
+
+// kubebuilderEnumPrefix starts the marker controller-gen validates a CRD field against.
+const kubebuilderEnumPrefix = "// +kubebuilder:validation:Enum="
+
+// KubebuilderMarker returns the kubebuilder validation marker listing the
+// values of the collection, to be put on the type so controller-gen only
+// accepts the declared values for every CRD field of it.
+//
+// Example:
+//
+//	// +kubebuilder:validation:Enum=deploy-all-the-things;deploy-one-thing
+func KubebuilderMarker(c Collection) (string, error) {
+	if len(c.Enums) == 0 {
+		return "", errors.New("collection has no enums, nothing to validate against")
+	}
+	if c.FieldName != "" || c.Interface {
+		return "", fmt.Errorf("%s isn't a string or number type and can't be a CRD enum", c.Type)
+	}
+
+	values := make([]string, len(c.Enums))
+	for i, e := range c.Enums {
+		values[i] = e.Unquoted()
+		if strings.Contains(values[i], ";") {
+			return "", fmt.Errorf("value of %s has a ; which separates the values of the marker: %s", e.Name, e.Value)
+		}
+	}
+
+	return kubebuilderEnumPrefix + strings.Join(values, ";"), nil
+}
+
+// WriteKubebuilderMarker writes the marker from KubebuilderMarker to w.
+func WriteKubebuilderMarker(w io.Writer, c Collection) error {
+	marker, err := KubebuilderMarker(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, marker)
+	return err
+}
+
+// PatchKubebuilderMarker returns the Go source file src with the
+// kubebuilder validation marker of the type of the collection replaced, or
+// added to the doc comment of the type if it doesn't have one, so the marker
+// can be kept up to date by go generate.
+func PatchKubebuilderMarker(src []byte, c Collection) ([]byte, error) {
+	marker, err := KubebuilderMarker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse source: %w", err)
+	}
+
+	name := typeName(c.Type)
+	for _, d := range f.Decls {
+		gen, ok := d.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.TypeSpec)
+			if spec.Name.Name != name {
+				continue
+			}
+
+			doc, decl := spec.Doc, ast.Node(spec)
+			if len(gen.Specs) == 1 && !gen.Lparen.IsValid() {
+				doc, decl = gen.Doc, gen
+			}
+
+			var patched []byte
+			if existing := kubebuilderEnumComment(doc); existing != nil {
+				start, end := fset.Position(existing.Pos()).Offset, fset.Position(existing.End()).Offset
+				patched = append(append(append(patched, src[:start]...), marker...), src[end:]...)
+			} else {
+				// Insert on a line of its own right above the declaration so it's part of the doc
+				pos := fset.Position(decl.Pos())
+				start := pos.Offset - (pos.Column - 1)
+				patched = append(append(append(patched, src[:start]...), marker+"\n"...), src[start:]...)
+			}
+
+			return format.Source(patched)
+		}
+	}
+
+	return nil, fmt.Errorf("type %s not declared in the source", name)
+}
+
+// kubebuilderEnumComment returns the kubebuilder enum marker in doc, if any.
+func kubebuilderEnumComment(doc *ast.CommentGroup) *ast.Comment {
+	if doc == nil {
+		return nil
+	}
+
+	for _, c := range doc.List {
+		if strings.HasPrefix(c.Text, kubebuilderEnumPrefix) {
+			return c
+		}
+	}
+
+	return nil
+}
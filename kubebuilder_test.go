@@ -0,0 +1,81 @@
+package enums_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestKubebuilderMarker(t *testing.T) {
+	t.Run("lists the unquoted values", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+
+		marker, err := enums.KubebuilderMarker(collection)
+		require.NoError(t, err)
+
+		require.Equal(t, "// +kubebuilder:validation:Enum=deploy-all-the-things;deploy-one-thing", marker)
+	})
+
+	t.Run("fails for struct enums", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.FlagStruct")
+		require.NoError(t, err)
+
+		_, err = enums.KubebuilderMarker(collection)
+
+		require.EqualError(t, err, "github.com/gaqzi/enums/testdata/full.FlagStruct isn't a string or number type and can't be a CRD enum")
+	})
+
+	t.Run("writes the marker on a line", func(t *testing.T) {
+		collection, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+
+		require.NoError(t, enums.WriteKubebuilderMarker(&buf, collection))
+
+		require.Equal(t, "// +kubebuilder:validation:Enum=deploy-all-the-things;deploy-one-thing\n", buf.String())
+	})
+}
+
+func TestPatchKubebuilderMarker(t *testing.T) {
+	collection := enums.Collection{
+		Type:  "example.com/api/v1.Phase",
+		Enums: []enums.Enum{{Name: "PhaseReady", Value: `"Ready"`}, {Name: "PhaseFailed", Value: `"Failed"`}},
+	}
+
+	t.Run("replaces an existing marker", func(t *testing.T) {
+		src := "package v1\n\n// Phase is where the resource is at.\n// +kubebuilder:validation:Enum=Ready\ntype Phase string\n"
+
+		patched, err := enums.PatchKubebuilderMarker([]byte(src), collection)
+		require.NoError(t, err)
+
+		require.Equal(t, "package v1\n\n// Phase is where the resource is at.\n// +kubebuilder:validation:Enum=Ready;Failed\ntype Phase string\n", string(patched))
+	})
+
+	t.Run("adds the marker to the doc of the type", func(t *testing.T) {
+		src := "package v1\n\n// Phase is where the resource is at.\ntype Phase string\n"
+
+		patched, err := enums.PatchKubebuilderMarker([]byte(src), collection)
+		require.NoError(t, err)
+
+		require.Equal(t, "package v1\n\n// Phase is where the resource is at.\n// +kubebuilder:validation:Enum=Ready;Failed\ntype Phase string\n", string(patched))
+	})
+
+	t.Run("adds the marker to a type in a group", func(t *testing.T) {
+		src := "package v1\n\ntype (\n\tName string\n\n\tPhase string\n)\n"
+
+		patched, err := enums.PatchKubebuilderMarker([]byte(src), collection)
+		require.NoError(t, err)
+
+		require.Equal(t, "package v1\n\ntype (\n\tName string\n\n\t// +kubebuilder:validation:Enum=Ready;Failed\n\tPhase string\n)\n", string(patched))
+	})
+
+	t.Run("fails when the type isn't in the source", func(t *testing.T) {
+		_, err := enums.PatchKubebuilderMarker([]byte("package v1\n"), collection)
+
+		require.EqualError(t, err, "type Phase not declared in the source")
+	})
+}
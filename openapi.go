@@ -0,0 +1,116 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FromOpenAPI builds a Collection from the enum of the schema at schemaPath
+// in an OpenAPI document, in either JSON or YAML, so tests can check that the
+// spec and the Go code agree. The path is a JSON pointer, such as
+// #/components/schemas/Flag, and a $ref in the schema is followed.
+//
+// The enums are named by the x-enum-varnames extension when the schema has
+// it and by their value otherwise. String values are quoted like string
+// literals in Go.
+//
+// Example:
+//
+//	spec, _ := FromOpenAPI(doc, "#/components/schemas/Flag")
+//	flags, _ := All("./feature", "feature.Flag")
+//	flags.Diff(spec.Values()) // the Go code has every value in the spec
+//	spec.Diff(flags.Values()) // the spec has every value in the Go code
+func FromOpenAPI(doc []byte, schemaPath string) (Collection, error) {
+	var root interface{}
+	if err := yaml.Unmarshal(doc, &root); err != nil {
+		return Collection{}, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	schema, err := resolvePointer(root, schemaPath)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	// Only follow a chain of references so far, a cycle would go on forever
+	for i := 0; i < 10; i++ {
+		m, ok := schema.(map[string]interface{})
+		if !ok {
+			break
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			break
+		}
+		if schema, err = resolvePointer(root, ref); err != nil {
+			return Collection{}, err
+		}
+	}
+
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		return Collection{}, fmt.Errorf("%s is not a schema", schemaPath)
+	}
+	values, ok := m["enum"].([]interface{})
+	if !ok {
+		return Collection{}, fmt.Errorf("schema %s has no enum", schemaPath)
+	}
+	names, _ := m["x-enum-varnames"].([]interface{})
+
+	collection := Collection{Type: schemaPath}
+	for i, v := range values {
+		var val string
+		switch v := v.(type) {
+		case string:
+			val = strconv.Quote(v)
+		case int, float64, bool:
+			val = fmt.Sprint(v)
+		default:
+			return Collection{}, fmt.Errorf("schema %s has an enum value that isn't a string, number, or boolean: %v", schemaPath, v)
+		}
+
+		name := Unquote(val)
+		if i < len(names) {
+			name = fmt.Sprint(names[i])
+		}
+
+		collection.Enums = append(collection.Enums, Enum{Name: name, Value: val, Type: schemaPath})
+	}
+
+	return collection, nil
+}
+
+// resolvePointer returns the value at the JSON pointer in doc, with or without a leading #.
+func resolvePointer(doc interface{}, pointer string) (interface{}, error) {
+	path := strings.TrimPrefix(strings.TrimPrefix(pointer, "#"), "/")
+	if path == "" {
+		return doc, nil
+	}
+
+	cur := doc
+	for _, part := range strings.Split(path, "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+
+		switch node := cur.(type) {
+		case map[string]interface{}:
+			next, ok := node[part]
+			if !ok {
+				return nil, fmt.Errorf("%s not found in OpenAPI document: no %q", pointer, part)
+			}
+			cur = next
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, fmt.Errorf("%s not found in OpenAPI document: no index %q", pointer, part)
+			}
+			cur = node[i]
+		default:
+			return nil, errors.New(pointer + " not found in OpenAPI document: " + part + " is past a value")
+		}
+	}
+
+	return cur, nil
+}
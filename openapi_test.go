@@ -0,0 +1,61 @@
+package enums_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestFromOpenAPI(t *testing.T) {
+	doc, err := os.ReadFile("testdata/openapi/spec.yaml")
+	require.NoError(t, err)
+
+	t.Run("builds a collection from the enum of the schema", func(t *testing.T) {
+		spec, err := enums.FromOpenAPI(doc, "#/components/schemas/Flag")
+		require.NoError(t, err)
+
+		require.Equal(
+			t,
+			[]enums.Enum{
+				{Name: "DeployAllTheThings", Value: `"deploy-all-the-things"`, Type: "#/components/schemas/Flag"},
+				{Name: "DeployOneThing", Value: `"deploy-one-thing"`, Type: "#/components/schemas/Flag"},
+			},
+			spec.Enums,
+		)
+	})
+
+	t.Run("agrees with the Go code in either direction", func(t *testing.T) {
+		spec, err := enums.FromOpenAPI(doc, "#/components/schemas/FeatureFlag")
+		require.NoError(t, err)
+		flags, err := enums.All("./testdata/full", "full.Flag")
+		require.NoError(t, err)
+
+		diff := flags.Diff(spec.Values())
+		require.Truef(t, diff.Zero(), "expected no differences: %s", diff)
+		diff = spec.Diff(flags.Values())
+		require.Truef(t, diff.Zero(), "expected no differences: %s", diff)
+	})
+
+	t.Run("names the values by themselves without x-enum-varnames", func(t *testing.T) {
+		spec, err := enums.FromOpenAPI(doc, "/components/schemas/Stage")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"1", "2", "3"}, spec.Values())
+		require.Equal(t, "1", spec.Enums[0].Name)
+	})
+
+	t.Run("fails for schemas without an enum", func(t *testing.T) {
+		_, err := enums.FromOpenAPI(doc, "#/components/schemas/Name")
+
+		require.EqualError(t, err, "schema #/components/schemas/Name has no enum")
+	})
+
+	t.Run("fails for paths not in the document", func(t *testing.T) {
+		_, err := enums.FromOpenAPI(doc, "#/components/schemas/Nope")
+
+		require.EqualError(t, err, `#/components/schemas/Nope not found in OpenAPI document: no "Nope"`)
+	})
+}
@@ -0,0 +1,238 @@
+package enums
+
+import (
+	"context"
+	"go/types"
+	"log/slog"
+	"time"
+)
+
+// Option configures how packages are loaded and scanned.
+type Option func(*config)
+
+type config struct {
+	dir      string   // the directory packages are loaded from, empty means the current directory
+	env      []string // the environment packages are loaded with, nil means the current environment
+	flags    []string // the build flags packages are loaded with, such as -mod=vendor or -tags=integration
+	logger   *slog.Logger
+	progress func(done, total int, pkg string) // nil means progress isn't reported
+	timeout  time.Duration                     // zero means no timeout
+
+	exportData  bool // read the types from export data instead of type checking the source
+	objects     bool // attach the types.Object and ast.ValueSpec of every enum
+	constrained bool // also scan the files excluded from the build by build constraints
+	noCgo       bool // load with cgo disabled, scanning the files using cgo without type checking them
+
+	cache         Cache          // where scans are stored between runs, nil means they aren't
+	registrations []registration // calls that register values, such as flags.Register("flag-x")
+	filters       []func(Enum, types.Object) bool
+}
+
+func newConfig(opts []Option) config {
+	c := config{logger: slog.New(slog.DiscardHandler)}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// context returns the context bounding a single scan.
+func (c config) context() (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithTimeout(context.Background(), c.timeout)
+}
+
+// WithDir loads the packages from dir instead of the current directory, so
+// relative patterns, go.mod, and go.work are resolved from it.
+//
+// Example:
+//
+//	All("example.com/orders/...", "orders.Status", WithDir("../workspace"))
+func WithDir(dir string) Option {
+	return func(c *config) {
+		c.dir = dir
+	}
+}
+
+// WithEnv loads the packages with env as the environment of the go command,
+// or the driver set with GOPACKAGESDRIVER for build systems like Bazel,
+// instead of the environment of the current process. It replaces the whole
+// environment, append to os.Environ() to change only some variables.
+//
+// Example:
+//
+//	All("./shell", "shell.Shell", WithEnv(append(os.Environ(), "GOOS=windows")))
+func WithEnv(env []string) Option {
+	return func(c *config) {
+		c.env = env
+	}
+}
+
+// WithBuildFlags loads the packages with flags passed to the go command,
+// or the driver set with GOPACKAGESDRIVER, such as -tags=integration. They
+// take precedence over the same flags set in GOFLAGS.
+//
+// Example:
+//
+//	All("./feature", "feature.Flag", WithBuildFlags("-tags=integration"))
+func WithBuildFlags(flags ...string) Option {
+	return func(c *config) {
+		c.flags = append(c.flags, flags...)
+	}
+}
+
+// WithVendor loads the packages of a module with a vendor directory from
+// it, even when GOFLAGS has another -mod setting such as -mod=mod, so the
+// dependencies resolve like they do when the module is built.
+//
+// Example:
+//
+//	All("./...", "flags.Flag", WithVendor())
+func WithVendor() Option {
+	return WithBuildFlags("-mod=vendor")
+}
+
+// WithLogger logs debug events while scanning to logger, such as how long
+// loading the packages took, which packages were visited, and which
+// declarations were skipped.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithProgress calls progress as the loaded packages are scanned, with the
+// number of packages scanned so far, the number of packages, and the import
+// path of the package just scanned, so a long scan can be told apart from a
+// hang. It's first called with done 0 and an empty pkg once the packages are
+// loaded. Scans read from a cache don't report progress.
+//
+// Example:
+//
+//	All("./...", "feature.Flag", WithProgress(func(done, total int, pkg string) {
+//		fmt.Fprintf(os.Stderr, "\rscanned %d/%d packages", done, total)
+//	}))
+func WithProgress(progress func(done, total int, pkg string)) Option {
+	return func(c *config) {
+		c.progress = progress
+	}
+}
+
+// reportProgress calls the progress callback of c, if any.
+func (c config) reportProgress(done, total int, pkg string) {
+	if c.progress != nil {
+		c.progress(done, total, pkg)
+	}
+}
+
+// WithTimeout bounds how long loading and scanning the packages may take.
+//
+// If loading the packages times out an error is returned, if scanning the
+// loaded packages times out the values found so far are returned together
+// with an error wrapping context.DeadlineExceeded.
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.timeout = d
+	}
+}
+
+// WithExportData reads the declarations from the compiled export data of the
+// packages instead of type checking their source, which is much faster for
+// large dependencies. Only constants have their values in the export data,
+// variables of the type are reported as Diagnostics.
+func WithExportData() Option {
+	return func(c *config) {
+		c.exportData = true
+	}
+}
+
+// WithConstrained also finds the enums declared in files excluded from the
+// current build by build constraints, such as shell_windows.go when running
+// on Linux, with the constraint in Enum.Constraints. As those files aren't
+// type checked only constants and variables declared with the type and a
+// basic literal are found, see DiffOptions.SoftConstrained.
+//
+// Example:
+//
+//	All("./shell", "shell.Shell", WithConstrained())
+func WithConstrained() Option {
+	return func(c *config) {
+		c.constrained = true
+	}
+}
+
+// WithoutCgo loads the packages with cgo disabled, so packages using cgo
+// neither fail to load without a C toolchain nor slow the scan down running
+// it. The files importing "C" are scanned without being type checked, like
+// with WithConstrained, and their enums have "cgo" in Enum.Constraints.
+//
+// Example:
+//
+//	All("./codec", "codec.Codec", WithoutCgo())
+func WithoutCgo() Option {
+	return func(c *config) {
+		c.noCgo = true
+	}
+}
+
+// WithObjects attaches the type checked object and the syntax of the
+// declaration to every enum, as Enum.Object and Enum.Spec, for tools doing
+// their own analysis on top of the enums without loading the packages again.
+func WithObjects() Option {
+	return func(c *config) {
+		c.objects = true
+	}
+}
+
+// WithCache reads the result of a scan from cache when none of the scanned
+// packages or their dependencies have changed since it was stored, and
+// stores it otherwise. Scans WithObjects or WithFilter aren't cached.
+//
+// Example:
+//
+//	All("./...", "feature.Flag", WithCache(FileCache{Dir: os.Getenv("ENUMS_CACHE")}))
+func WithCache(cache Cache) Option {
+	return func(c *config) {
+		c.cache = cache
+	}
+}
+
+// WithRegistration reads the values of variables assigned from a call to
+// the function fn from its argument at index arg, counting from 0, for
+// values registered with an SDK such as:
+//
+//	var FlagX = flags.Register("flag-x", flags.WithDefault(true))
+//
+// fn is the function as called, such as flags.Register, or its fully
+// qualified name, such as github.com/acme/flags.Register. The argument has
+// to be a constant, other arguments are reported as Diagnostics.
+//
+// Example:
+//
+//	All("./feature", "flags.Flag", WithRegistration("flags.Register", 0))
+func WithRegistration(fn string, arg int) Option {
+	return func(c *config) {
+		c.registrations = append(c.registrations, registration{fn: fn, arg: arg})
+	}
+}
+
+// WithFilter only keeps the enums filter returns true for, as they're
+// found, such as to only keep enums following a naming convention or
+// declared in some directories. obj is the declaration as type checked,
+// nil for the enums found in files excluded from the build WithConstrained
+// or WithoutCgo, as those files aren't type checked.
+//
+// Example:
+//
+//	All("./...", "feature.Flag", WithFilter(func(e Enum, obj types.Object) bool {
+//		return strings.HasPrefix(e.Name, "Flag")
+//	}))
+func WithFilter(filter func(e Enum, obj types.Object) bool) Option {
+	return func(c *config) {
+		c.filters = append(c.filters, filter)
+	}
+}
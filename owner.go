@@ -0,0 +1,52 @@
+package enums
+
+import (
+	"sort"
+	"strings"
+)
+
+// MissingByOwner splits the enums missing from d by their owner from the
+// //enums:owner directive, with the enums without an owner under the empty
+// string, so drift can be routed to the team owning the values.
+//
+// Example:
+//
+//	for owner, missing := range diff.MissingByOwner() {
+//		notify(owner, missing.Values())
+//	}
+func (d Diff) MissingByOwner() map[string]Collection {
+	if len(d.Missing.Enums) == 0 {
+		return nil
+	}
+
+	return d.Missing.GroupBy("owner")
+}
+
+// OwnerReport outputs the enums missing from d grouped by owner, sorted by
+// owner with the enums without one last, or an empty string when none are
+// missing.
+func (d Diff) OwnerReport() string {
+	byOwner := d.MissingByOwner()
+	owners := make([]string, 0, len(byOwner))
+	for owner := range byOwner {
+		if owner != "" {
+			owners = append(owners, owner)
+		}
+	}
+	sort.Strings(owners)
+	if _, ok := byOwner[""]; ok {
+		owners = append(owners, "")
+	}
+
+	var b strings.Builder
+	for _, owner := range owners {
+		if owner == "" {
+			b.WriteString("Enums declared but not part of actual without an owner:\n")
+		} else {
+			b.WriteString("Enums declared but not part of actual owned by " + owner + ":\n")
+		}
+		b.WriteString(byOwner[owner].declared())
+	}
+
+	return b.String()
+}
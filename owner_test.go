@@ -0,0 +1,49 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestDiff_OwnerReport(t *testing.T) {
+	matches, err := enums.All("./testdata/owner", "owner.Flag")
+	require.NoError(t, err)
+
+	t.Run("reads the owner from the directive", func(t *testing.T) {
+		var owners []string
+		for _, e := range matches.Enums {
+			owners = append(owners, e.Name+": "+e.Owner())
+		}
+
+		require.Equal(t, []string{"FlagBeta: ", "FlagInvoices: team-payments", "FlagRefunds: team-payments", "FlagSearch: team-discovery"}, owners)
+	})
+
+	t.Run("groups the missing enums by owner", func(t *testing.T) {
+		diff := matches.Diff([]string{"refunds"})
+
+		byOwner := diff.MissingByOwner()
+		require.Equal(t, []string{"invoices"}, byOwner["team-payments"].Values())
+		require.Equal(t, []string{"search"}, byOwner["team-discovery"].Values())
+		require.Equal(t, []string{"beta"}, byOwner[""].Values())
+		require.Equal(
+			t,
+			"Enums declared but not part of actual owned by team-discovery:\n"+
+				"\tFlagSearch = \"search\" (declared at testdata/owner/example.go:9)\n"+
+				"Enums declared but not part of actual owned by team-payments:\n"+
+				"\tFlagInvoices = \"invoices\" (declared at testdata/owner/example.go:7)\n"+
+				"Enums declared but not part of actual without an owner:\n"+
+				"\tFlagBeta = \"beta\" (declared at testdata/owner/example.go:12)\n",
+			diff.OwnerReport(),
+		)
+	})
+
+	t.Run("is empty when nothing is missing", func(t *testing.T) {
+		diff := matches.Diff([]string{"beta", "invoices", "refunds", "search"})
+
+		require.Nil(t, diff.MissingByOwner())
+		require.Empty(t, diff.OwnerReport())
+	})
+}
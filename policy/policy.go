@@ -0,0 +1,134 @@
+// Package policy lints enums against rules on their values and
+// declarations, such as a maximum length or a required doc comment, so the
+// conventions of a team's flags or event names are enforced where they're
+// declared instead of where they're first used.
+//
+// Example:
+//
+//	collection, _ := enums.All("./feature", "feature.Flag")
+//	violations := policy.Policy{
+//		policy.MaxLength(32),
+//		policy.Charset("a-z0-9-"),
+//		policy.ReservedPrefixes("internal-"),
+//		policy.RequireDoc(),
+//	}.Check(collection)
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gaqzi/enums"
+)
+
+// Rule is a named check of a single enum.
+type Rule struct {
+	Name string
+	// Check returns why e violates the rule, or an empty string if it doesn't.
+	Check func(e enums.Enum) string
+}
+
+// Policy is the rules every enum of a collection has to follow.
+type Policy []Rule
+
+// Check returns the violations of the rules by the enums of collection, in
+// the order of the enums and then of the rules.
+func (p Policy) Check(collection enums.Collection) Violations {
+	var violations Violations
+	for _, e := range collection.Enums {
+		for _, rule := range p {
+			if msg := rule.Check(e); msg != "" {
+				violations = append(violations, Violation{Enum: e, Rule: rule.Name, Message: msg})
+			}
+		}
+	}
+
+	return violations
+}
+
+// Violation is an enum not following a rule.
+type Violation struct {
+	Enum    enums.Enum
+	Rule    string // the name of the rule, such as max-length
+	Message string // why the enum violates the rule
+}
+
+// String outputs the violation in the file:line:col: message format of go vet.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s = %s: %s (%s)", v.Enum.Pos, v.Enum.Name, v.Enum.Value, v.Message, v.Rule)
+}
+
+// Violations are the violations of a policy by a collection.
+type Violations []Violation
+
+// ByEnum returns the violations by the name of the enum violating them.
+func (v Violations) ByEnum() map[string]Violations {
+	byEnum := make(map[string]Violations)
+	for _, violation := range v {
+		byEnum[violation.Enum.Name] = append(byEnum[violation.Enum.Name], violation)
+	}
+
+	return byEnum
+}
+
+// String outputs every violation on a line of its own, or an empty string
+// when there are none.
+func (v Violations) String() string {
+	var b strings.Builder
+	for _, violation := range v {
+		b.WriteString(violation.String() + "\n")
+	}
+
+	return b.String()
+}
+
+// MaxLength is violated by values longer than n characters, with string
+// literals unquoted.
+func MaxLength(n int) Rule {
+	return Rule{Name: "max-length", Check: func(e enums.Enum) string {
+		if l := len([]rune(e.Unquoted())); l > n {
+			return fmt.Sprintf("value is %d characters, longer than %d", l, n)
+		}
+
+		return ""
+	}}
+}
+
+// Charset is violated by values with characters outside of chars, given
+// like the contents of a regexp character class such as a-z0-9-.
+func Charset(chars string) Rule {
+	outside := regexp.MustCompile("[^" + chars + "]")
+	return Rule{Name: "charset", Check: func(e enums.Enum) string {
+		if c := outside.FindString(e.Unquoted()); c != "" {
+			return fmt.Sprintf("value has %q, not in %s", c, chars)
+		}
+
+		return ""
+	}}
+}
+
+// ReservedPrefixes is violated by values starting with any of prefixes,
+// such as prefixes reserved for values of another team or a vendor.
+func ReservedPrefixes(prefixes ...string) Rule {
+	return Rule{Name: "reserved-prefix", Check: func(e enums.Enum) string {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(e.Unquoted(), prefix) {
+				return fmt.Sprintf("value starts with the reserved prefix %q", prefix)
+			}
+		}
+
+		return ""
+	}}
+}
+
+// RequireDoc is violated by enums without a doc or line comment.
+func RequireDoc() Rule {
+	return Rule{Name: "require-doc", Check: func(e enums.Enum) string {
+		if strings.TrimSpace(e.Doc) == "" {
+			return "missing doc comment"
+		}
+
+		return ""
+	}}
+}
@@ -0,0 +1,69 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+	"github.com/gaqzi/enums/policy"
+)
+
+func TestPolicy_Check(t *testing.T) {
+	collection, err := enums.All("../testdata/stats", "stats.Flag")
+	require.NoError(t, err)
+
+	violated := func(v policy.Violations) []string {
+		var out []string
+		for _, violation := range v {
+			out = append(out, violation.Enum.Name+": "+violation.Message+" ("+violation.Rule+")")
+		}
+		return out
+	}
+
+	t.Run("returns nothing when every enum follows the rules", func(t *testing.T) {
+		violations := policy.Policy{policy.MaxLength(6), policy.Charset("a-z")}.Check(collection)
+
+		require.Empty(t, violations)
+		require.Empty(t, violations.String())
+	})
+
+	t.Run("returns the violations in the order of the enums and rules", func(t *testing.T) {
+		violations := policy.Policy{
+			policy.MaxLength(2),
+			policy.Charset("a-n"),
+			policy.ReservedPrefixes("le", "of"),
+			policy.RequireDoc(),
+		}.Check(collection)
+
+		require.Equal(t, []string{
+			`FlagLegacy: value is 6 characters, longer than 2 (max-length)`,
+			`FlagLegacy: value has "y", not in a-n (charset)`,
+			`FlagLegacy: value starts with the reserved prefix "le" (reserved-prefix)`,
+			`FlagOff: value is 3 characters, longer than 2 (max-length)`,
+			`FlagOff: value has "o", not in a-n (charset)`,
+			`FlagOff: value starts with the reserved prefix "of" (reserved-prefix)`,
+			`FlagOff: missing doc comment (require-doc)`,
+			`FlagOn: value has "o", not in a-n (charset)`,
+			`FlagOn: missing doc comment (require-doc)`,
+		}, violated(violations))
+		require.Len(t, violations.ByEnum()["FlagOn"], 2)
+	})
+
+	t.Run("outputs the violations like go vet", func(t *testing.T) {
+		violations := policy.Policy{policy.ReservedPrefixes("le")}.Check(collection)
+
+		require.Equal(t, collection.Enums[0].Pos.String()+`: FlagLegacy = "legacy": value starts with the reserved prefix "le" (reserved-prefix)`+"\n", violations.String())
+	})
+
+	t.Run("runs custom rules", func(t *testing.T) {
+		violations := policy.Policy{{Name: "no-on", Check: func(e enums.Enum) string {
+			if e.Name == "FlagOn" {
+				return "on is implied"
+			}
+			return ""
+		}}}.Check(collection)
+
+		require.Equal(t, []string{"FlagOn: on is implied (no-on)"}, violated(violations))
+	})
+}
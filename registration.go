@@ -0,0 +1,80 @@
+package enums
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// registration is a function that registers values, and the index of the
+// argument the value is passed as.
+type registration struct {
+	fn  string
+	arg int
+}
+
+// registration returns the call to a registration function assigned to the
+// i:th name of spec, if there is one.
+func (c config) registration(p *packages.Package, spec *ast.ValueSpec, i int) (*ast.CallExpr, registration, bool) {
+	if len(c.registrations) == 0 || len(spec.Values) != len(spec.Names) {
+		return nil, registration{}, false
+	}
+
+	call, ok := ast.Unparen(spec.Values[i]).(*ast.CallExpr)
+	if !ok {
+		return nil, registration{}, false
+	}
+
+	for _, reg := range c.registrations {
+		if reg.calls(p, call) {
+			return call, reg, true
+		}
+	}
+
+	return nil, registration{}, false
+}
+
+// calls returns whether call is a call to the registration function.
+func (r registration) calls(p *packages.Package, call *ast.CallExpr) bool {
+	if types.ExprString(call.Fun) == r.fn {
+		return true
+	}
+
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return false
+	}
+
+	fn, ok := p.TypesInfo.Uses[ident].(*types.Func)
+	if !ok {
+		return false
+	}
+
+	name := fn.FullName()
+	return name == r.fn || strings.HasSuffix(name, "/"+r.fn)
+}
+
+// value returns the value passed to the registration function in call.
+func (r registration) value(p *packages.Package, call *ast.CallExpr) (string, error) {
+	if r.arg >= len(call.Args) {
+		return "", fmt.Errorf("%s is called with %d arguments, the value is expected as argument %d", r.fn, len(call.Args), r.arg)
+	}
+
+	arg := call.Args[r.arg]
+	if lit, ok := arg.(*ast.BasicLit); ok {
+		return lit.Value, nil
+	}
+	if tv := p.TypesInfo.Types[arg]; tv.Value != nil {
+		return constValue(tv.Value), nil
+	}
+
+	return "", fmt.Errorf("argument %d to %s is not a constant, the value is only known at runtime", r.arg, r.fn)
+}
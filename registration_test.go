@@ -0,0 +1,39 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestAll_WithRegistration(t *testing.T) {
+	t.Run("reads the value from the argument of the registration call", func(t *testing.T) {
+		for _, fn := range []string{"Register", "github.com/gaqzi/enums/testdata/registration.Register"} {
+			matches, err := enums.All("./testdata/registration", "registration.Flag", enums.WithRegistration(fn, 0))
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"flag-x", "flag-y", "flag-z"}, matches.Values(), fn)
+			require.Equal(t, "FlagX is on by default.", matches.Enums[0].Doc)
+			require.Len(t, matches.Diagnostics, 1)
+			require.Equal(t, "FlagRuntime", matches.Diagnostics[0].Name)
+			require.Equal(t, "argument 0 to "+fn+" is not a constant, the value is only known at runtime", matches.Diagnostics[0].Reason)
+		}
+	})
+
+	t.Run("explains calls without the argument", func(t *testing.T) {
+		matches, err := enums.All("./testdata/registration", "registration.Flag", enums.WithRegistration("Register", 2))
+		require.NoError(t, err)
+
+		require.Empty(t, matches.Enums)
+		require.Equal(t, "Register is called with 2 arguments, the value is expected as argument 2", matches.Diagnostics[0].Reason)
+	})
+
+	t.Run("skips registered values without the option", func(t *testing.T) {
+		matches, err := enums.All("./testdata/registration", "registration.Flag")
+		require.NoError(t, err)
+
+		require.Empty(t, matches.Enums)
+	})
+}
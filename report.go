@@ -0,0 +1,94 @@
+package enums
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WriteHTMLReport writes a static HTML page listing the enums of every
+// collection grouped by package, with a search box filtering the values, to
+// be published as an inventory of the enums in a module.
+//
+// Example:
+//
+//	set, _ := AllTypes("./...", []string{"feature.Flag", "billing.Plan"})
+//	WriteHTMLReport(os.Stdout, set["feature.Flag"], set["billing.Plan"])
+func WriteHTMLReport(w io.Writer, collections ...Collection) error {
+	byPkg := make(map[string][]reportType)
+	for _, c := range collections {
+		if c.Type == "" {
+			continue // nothing was found so there's nothing to list
+		}
+
+		dot := strings.LastIndex(c.Type, ".")
+		pkg := c.Type[:dot]
+		byPkg[pkg] = append(byPkg[pkg], reportType{Name: c.Type[dot+1:], Collection: c})
+	}
+
+	var pkgs []reportPackage
+	for path, types := range byPkg {
+		sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+		pkgs = append(pkgs, reportPackage{Path: path, Types: types})
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Path < pkgs[j].Path })
+
+	return reportTemplate.Execute(w, pkgs)
+}
+
+type reportPackage struct {
+	Path  string
+	Types []reportType
+}
+
+type reportType struct {
+	Name string
+	Collection
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"file": func(c Collection, e Enum) string { return c.relativeFile(e.Pos.Filename) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Enums</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+code { white-space: pre; }
+</style>
+</head>
+<body>
+<h1>Enums</h1>
+<input id="search" type="search" placeholder="Search names, values, and docs" autofocus>
+{{- range .}}
+<section class="package">
+<h2>{{.Path}}</h2>
+{{- range .Types}}
+<h3 id="{{.Type}}">{{.Name}}</h3>
+<table>
+<thead><tr><th>Name</th><th>Value</th><th>Doc</th><th>Declared</th></tr></thead>
+<tbody>
+{{- $c := .Collection}}
+{{- range .Enums}}
+<tr class="enum"><td>{{.Name}}</td><td><code>{{.Value}}</code></td><td>{{.Doc}}</td><td>{{file $c .}}{{if .Pos.Line}}:{{.Pos.Line}}{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</section>
+{{- end}}
+<script>
+document.getElementById("search").addEventListener("input", function (e) {
+  var q = e.target.value.toLowerCase();
+  document.querySelectorAll("tr.enum").forEach(function (row) {
+    row.hidden = q !== "" && row.textContent.toLowerCase().indexOf(q) === -1;
+  });
+});
+</script>
+</body>
+</html>
+`))
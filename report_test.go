@@ -0,0 +1,37 @@
+package enums_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestWriteHTMLReport(t *testing.T) {
+	set, err := enums.AllTypes("./testdata/full", []string{"full.Flag", "full.FlagStruct"})
+	require.NoError(t, err)
+	multi, err := enums.All("./testdata/multimatch", "multimatch.Flag")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+
+	require.NoError(t, enums.WriteHTMLReport(&buf, multi, set["full.FlagStruct"], set["full.Flag"], enums.Collection{}))
+
+	report := buf.String()
+	require.Contains(t, report, `<input id="search"`)
+	require.Contains(
+		t,
+		report,
+		`<tr class="enum"><td>DeployOneThing</td><td><code>&#34;deploy-one-thing&#34;</code></td><td></td><td>testdata/full/example.go:7</td></tr>`,
+		"expected the values to be escaped",
+	)
+	require.Contains(t, report, `<td>with a line comment</td>`)
+
+	full := strings.Index(report, "<h2>github.com/gaqzi/enums/testdata/full</h2>")
+	flag := strings.Index(report, ">Flag</h3>")
+	flagStruct := strings.Index(report, ">FlagStruct</h3>")
+	multimatch := strings.Index(report, "<h2>github.com/gaqzi/enums/testdata/multimatch</h2>")
+	require.True(t, full < flag && flag < flagStruct && flagStruct < multimatch, "expected the types grouped and sorted by package")
+}
@@ -0,0 +1,64 @@
+package enums
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// AllAtRev finds variables of typ in pkg as they were declared at the git revision rev.
+//
+// The revision is checked out into a temporary worktree which is removed once
+// the scan is done, so the current checkout is never touched. pkg is resolved
+// the same way as for All, relative to the current directory.
+//
+// Example:
+//
+//	AllAtRev("v1.4.0", "./feature", "feature.Flag")
+func AllAtRev(rev, pkg, typ string, opts ...Option) (Collection, error) {
+	root, err := git("", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return Collection{}, fmt.Errorf("failed to find git repository: %w", err)
+	}
+
+	// The current directory relative to the root, so pkg resolves the same in the worktree
+	prefix, err := git("", "rev-parse", "--show-prefix")
+	if err != nil {
+		return Collection{}, fmt.Errorf("failed to find git repository: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "enums-rev-")
+	if err != nil {
+		return Collection{}, fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := git(root, "worktree", "add", "--detach", dir, rev); err != nil {
+		return Collection{}, fmt.Errorf("failed to check out revision %q: %w", rev, err)
+	}
+	defer git(root, "worktree", "remove", "--force", dir) // nolint:errcheck
+
+	c := newConfig(opts)
+	c.dir = filepath.Join(dir, prefix)
+
+	return all(c, pkg, typ)
+}
+
+// git runs a git command in dir and returns its trimmed output.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,165 @@
+package enums
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/types"
+	"regexp"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// CollectionSet holds the Collections for several types found in a single load, keyed by the type as queried.
+type CollectionSet map[string]Collection
+
+// AllTypes finds variables of each of types in pkg, loading pkg only once.
+//
+// Like All, the set holds what could be found when some packages fail to
+// load, along with the errors, and the declarations that fail to extract
+// are in the Diagnostics of each collection.
+//
+// Example:
+//
+//	AllTypes("./feature", []string{"feature.Flag", "feature.Stage"})
+func AllTypes(pkg string, types []string, opts ...Option) (CollectionSet, error) {
+	c := newConfig(opts)
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, loadErr := load(ctx, c, pkg)
+	if len(pkgs) == 0 && loadErr != nil {
+		return nil, loadErr
+	}
+
+	return collectSet(ctx, c, pkgs, types, loadErr)
+}
+
+// AllMatching finds variables of every type declared in pkg whose fully
+// qualified name, such as github.com/acme/app/order.Status, matches re. The
+// set is keyed by the fully qualified names, for checking conventions
+// across an organization rather than one type at a time.
+//
+// Like AllTypes, the set holds the types found in the packages that loaded
+// when some fail to load, along with the errors.
+//
+// Example:
+//
+//	AllMatching("./...", regexp.MustCompile(`Status$`))
+func AllMatching(pkg string, re *regexp.Regexp, opts ...Option) (CollectionSet, error) {
+	c := newConfig(opts)
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, loadErr := load(ctx, c, pkg)
+	if len(pkgs) == 0 && loadErr != nil {
+		return nil, loadErr
+	}
+
+	var matched []string
+	for _, p := range pkgs {
+		if p.Types == nil {
+			continue
+		}
+
+		scope := p.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if ok && !obj.IsAlias() && re.MatchString(obj.Type().String()) {
+				matched = append(matched, obj.Type().String())
+			}
+		}
+	}
+
+	return collectSet(ctx, c, pkgs, matched, loadErr)
+}
+
+// collectSet collects each of typs from pkgs in a single pass, keeping the
+// collections of every type with the errors of the others joined with
+// loadErr, the error loading pkgs.
+func collectSet(ctx context.Context, c config, pkgs []*packages.Package, typs []string, loadErr error) (CollectionSet, error) {
+	set := make(CollectionSet, len(typs))
+	errs := []error{loadErr}
+	collections, collectErrs := collectEach(ctx, c, pkgs, typs)
+	for i, typ := range typs {
+		err := collectErrs[i]
+		var notFound *TypeNotFoundError
+		if loadErr != nil && errors.As(err, &notFound) {
+			err = nil // the type may well be declared in a package that failed to load
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", typ, err))
+		}
+
+		set[typ] = collections[i]
+	}
+
+	return set, errors.Join(errs...)
+}
+
+// Get returns the Collection for typ and whether it was part of the set.
+func (s CollectionSet) Get(typ string) (Collection, bool) {
+	c, ok := s[typ]
+	return c, ok
+}
+
+// Diff diffs every Collection in the set against the slice in actual for the same type.
+//
+// A type without an entry in actual is diffed against an empty slice,
+// so all of its enums are reported as missing.
+func (s CollectionSet) Diff(actual map[string]interface{}) DiffSet {
+	for typ := range actual {
+		if _, ok := s[typ]; !ok {
+			panic(fmt.Sprintf("Diff: no collection for type in actual: %s", typ))
+		}
+	}
+
+	diffs := make(DiffSet, len(s))
+	for typ, c := range s {
+		values, ok := actual[typ]
+		if !ok {
+			values = []interface{}{}
+		}
+
+		diffs[typ] = c.Diff(values)
+	}
+
+	return diffs
+}
+
+// DiffSet contains the result of diffing a CollectionSet, keyed by the type as queried.
+type DiffSet map[string]Diff
+
+// Zero returns whether there is nothing in any of the diffs.
+func (d DiffSet) Zero() bool {
+	for _, diff := range d {
+		if !diff.Zero() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String outputs a human summary of every non-zero diff, ordered by type.
+func (d DiffSet) String() string {
+	types := make([]string, 0, len(d))
+	for typ, diff := range d {
+		if !diff.Zero() {
+			types = append(types, typ)
+		}
+	}
+	sort.Strings(types)
+
+	var msg string
+	for _, typ := range types {
+		msg += typ + ":\n" + d[typ].String()
+	}
+
+	if len(msg) > 0 {
+		return msg
+	}
+
+	return "<DiffSet{}>"
+}
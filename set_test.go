@@ -0,0 +1,122 @@
+package enums_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+	"github.com/gaqzi/enums/testdata/full"
+)
+
+func TestAllTypes(t *testing.T) {
+	set, err := enums.AllTypes("./testdata/full", []string{"full.Flag", "full.FlagStruct"})
+	require.NoError(t, err)
+
+	t.Run("holds a collection per type", func(t *testing.T) {
+		for _, typ := range []string{"full.Flag", "full.FlagStruct"} {
+			expected, err := enums.All("./testdata/full", typ)
+			require.NoError(t, err)
+
+			actual, ok := set.Get(typ)
+			require.True(t, ok, "expected to have a collection for %s", typ)
+			require.Equal(t, expected, actual)
+		}
+	})
+
+	t.Run("Get reports types not in the set", func(t *testing.T) {
+		_, ok := set.Get("full.Other")
+
+		require.False(t, ok)
+	})
+
+	t.Run("Diff is zero when all types are handled", func(t *testing.T) {
+		diff := set.Diff(map[string]interface{}{
+			"full.Flag":       full.AllFlags(),
+			"full.FlagStruct": full.AllFlagStruct(),
+		})
+
+		require.True(t, diff.Zero(), "expected no differences: %s", diff)
+		require.Equal(t, "<DiffSet{}>", diff.String())
+	})
+
+	t.Run("Diff reports types missing from actual as missing all enums", func(t *testing.T) {
+		diff := set.Diff(map[string]interface{}{
+			"full.Flag": full.MissingFlags(),
+		})
+
+		require.False(t, diff.Zero())
+		require.Equal(
+			t,
+			"full.Flag:\n"+
+				"Enums declared but not part of actual:\n"+
+				"\tDeployOneThing = \"deploy-one-thing\" (declared at testdata/full/example.go:7)\n"+
+				"full.FlagStruct:\n"+
+				"Enums declared but not part of actual:\n"+
+				"\tFlagDefaultOn = \"flag-default-on\" (declared at testdata/full/example_struct.go:9)\n",
+			diff.String(),
+		)
+	})
+
+	t.Run("Diff panics for types not in the set", func(t *testing.T) {
+		require.Panics(t, func() {
+			set.Diff(map[string]interface{}{"full.Other": []string{}})
+		})
+	})
+}
+
+func TestAllTypes_Options(t *testing.T) {
+	t.Run("scans with the options", func(t *testing.T) {
+		set, err := enums.AllTypes("./full", []string{"full.Flag"}, enums.WithDir("./testdata"))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"deploy-all-the-things", "deploy-one-thing"}, set["full.Flag"].Values())
+	})
+
+	t.Run("returns the enums of the packages that loaded with the errors", func(t *testing.T) {
+		set, err := enums.AllTypes("./testdata/partial/...", []string{"partial.Flag"})
+
+		require.ErrorContains(t, err, "failed to load package: github.com/gaqzi/enums/testdata/partial/broken: ")
+		require.Equal(t, []string{"off", "on"}, set["partial.Flag"].Values())
+	})
+}
+
+func TestAllMatching(t *testing.T) {
+	set, err := enums.AllMatching("./testdata/full", regexp.MustCompile(`Flag$`))
+	require.NoError(t, err)
+
+	var types []string
+	for typ := range set {
+		types = append(types, typ)
+	}
+	require.ElementsMatch(
+		t,
+		[]string{"github.com/gaqzi/enums/testdata/full.Flag", "github.com/gaqzi/enums/testdata/full.EmbeddedFlag"},
+		types,
+	)
+
+	expected, err := enums.All("./testdata/full", "github.com/gaqzi/enums/testdata/full.EmbeddedFlag")
+	require.NoError(t, err)
+	actual, _ := set.Get("github.com/gaqzi/enums/testdata/full.EmbeddedFlag")
+	require.Equal(t, expected, actual)
+}
+
+func TestAllMatching_LoadErrors(t *testing.T) {
+	set, err := enums.AllMatching("./testdata/partial/...", regexp.MustCompile(`partial\.Flag$`))
+
+	require.ErrorContains(t, err, "failed to load package: github.com/gaqzi/enums/testdata/partial/broken: ")
+	require.Equal(t, []string{"off", "on"}, set["github.com/gaqzi/enums/testdata/partial.Flag"].Values())
+}
+
+func TestAllMatching_WithProgress(t *testing.T) {
+	var progress []string
+	set, err := enums.AllMatching("./testdata/full", regexp.MustCompile(`Flag$`), enums.WithProgress(func(done, total int, pkg string) {
+		progress = append(progress, fmt.Sprintf("%d/%d %s", done, total, pkg))
+	}))
+	require.NoError(t, err)
+	require.Len(t, set, 2)
+
+	require.Equal(t, []string{"0/1 ", "1/1 github.com/gaqzi/enums/testdata/full"}, progress, "expected the packages to be scanned once for every type")
+}
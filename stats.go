@@ -0,0 +1,90 @@
+package enums
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stats counts the enums of several types, for tracking flag debt on
+// dashboards over time. It's written as JSON by the stats command.
+type Stats struct {
+	Types      int         `json:"types"`
+	Values     int         `json:"values"`
+	Deprecated int         `json:"deprecated"`
+	Unused     int         `json:"unused"`
+	PerType    []TypeStats `json:"per_type"` // in the order the types were given
+}
+
+// TypeStats counts the enums of a single type.
+type TypeStats struct {
+	Type       string   `json:"type"`
+	Values     int      `json:"values"`
+	Deprecated []string `json:"deprecated"` // the names of the enums with a Deprecated: paragraph in their doc
+	Unused     []string `json:"unused"`     // the names of the enums never referenced in the loaded packages
+}
+
+// Deprecated returns whether the doc comment of the enum has a paragraph
+// starting with "Deprecated: ", the Go convention for deprecated identifiers.
+func (e Enum) Deprecated() bool {
+	for _, paragraph := range strings.Split(e.Doc, "\n\n") {
+		if strings.HasPrefix(paragraph, "Deprecated: ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CollectStats counts the enums of each of types in pkg, loading pkg only
+// once. An enum is unused when no package matching pkg references it, so
+// load the whole module with ./... to count the uses outside the package
+// declaring the type.
+//
+// Example:
+//
+//	CollectStats("./...", []string{"feature.Flag", "billing.Plan"})
+func CollectStats(pkg string, types []string, opts ...Option) (Stats, error) {
+	c := newConfig(opts)
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, err := load(ctx, c, pkg)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	used := make(map[string]bool)
+	for _, p := range pkgs {
+		for _, obj := range p.TypesInfo.Uses {
+			if obj.Pkg() != nil {
+				used[objectName(obj)] = true
+			}
+		}
+	}
+
+	stats := Stats{Types: len(types), PerType: make([]TypeStats, 0, len(types))}
+	collections, errs := collectEach(ctx, c, pkgs, types)
+	for i, typ := range types {
+		collection, err := collections[i], errs[i]
+		if err != nil {
+			return Stats{}, fmt.Errorf("%s: %w", typ, err)
+		}
+
+		ts := TypeStats{Type: typ, Values: len(collection.Enums), Deprecated: []string{}, Unused: []string{}}
+		for _, e := range collection.Enums {
+			if e.Deprecated() {
+				ts.Deprecated = append(ts.Deprecated, e.Name)
+			}
+			if !used[e.qualifiedName()] {
+				ts.Unused = append(ts.Unused, e.Name)
+			}
+		}
+
+		stats.Values += ts.Values
+		stats.Deprecated += len(ts.Deprecated)
+		stats.Unused += len(ts.Unused)
+		stats.PerType = append(stats.PerType, ts)
+	}
+
+	return stats, nil
+}
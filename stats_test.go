@@ -0,0 +1,50 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestCollectStats(t *testing.T) {
+	stats, err := enums.CollectStats("./testdata/stats", []string{"stats.Flag", "stats.Stage"})
+	require.NoError(t, err)
+
+	require.Equal(
+		t,
+		enums.Stats{
+			Types:      2,
+			Values:     5,
+			Deprecated: 1,
+			Unused:     2,
+			PerType: []enums.TypeStats{
+				{Type: "stats.Flag", Values: 3, Deprecated: []string{"FlagLegacy"}, Unused: []string{"FlagLegacy"}},
+				{Type: "stats.Stage", Values: 2, Deprecated: []string{}, Unused: []string{"StageBeta"}},
+			},
+		},
+		stats,
+	)
+}
+
+func TestEnum_Deprecated(t *testing.T) {
+	require.True(t, enums.Enum{Doc: "FlagLegacy was the first flag.\n\nDeprecated: use FlagOn."}.Deprecated())
+	require.True(t, enums.Enum{Doc: "Deprecated: use FlagOn."}.Deprecated())
+	require.False(t, enums.Enum{Doc: "FlagOn replaces the Deprecated: FlagLegacy."}.Deprecated())
+}
+
+func TestCollectStats_DeclaredInAnotherPackage(t *testing.T) {
+	stats, err := enums.CollectStats("./testdata/crosspkg/...", []string{"a.Flag"})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"FlagUnused"}, stats.PerType[0].Unused)
+}
+
+func TestCollectStats_Options(t *testing.T) {
+	stats, err := enums.CollectStats("./crosspkg/...", []string{"a.Flag"}, enums.WithDir("./testdata"))
+	require.NoError(t, err)
+
+	require.Equal(t, 4, stats.Values)
+	require.Equal(t, []string{"FlagUnused"}, stats.PerType[0].Unused)
+}
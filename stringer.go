@@ -0,0 +1,264 @@
+package enums
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// StringerDiff checks that the String method of typ in pkg has a name for
+// every constant of the type, by reading the method body instead of calling
+// it. Missing has the constants without a name and Extra the values with a
+// name that no constant has, which for generated methods means they're stale.
+//
+// Methods switching on the value, looking it up in a map literal, and those
+// generated by stringer (https://pkg.go.dev/golang.org/x/tools/cmd/stringer)
+// are understood. Unlike All, every constant is checked, including those
+// declared with iota, and the values are compared as constants.
+//
+// Example:
+//
+//	diff, _ := StringerDiff("./feature", "feature.Level")
+func StringerDiff(pkg, typ string, opts ...Option) (Diff, error) {
+	c := newConfig(opts)
+	if c.exportData {
+		return Diff{}, errors.New("checking a String method needs its source, it can't be done from export data")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, err := load(ctx, c, pkg)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	for _, p := range pkgs {
+		for _, f := range p.Syntax {
+			for _, d := range f.Decls {
+				fn, ok := d.(*ast.FuncDecl)
+				if !ok || fn.Recv == nil || fn.Name.Name != "String" || fn.Body == nil {
+					continue
+				}
+
+				named := receiver(p, fn)
+				if named == nil || !matchesType(named, typ) {
+					continue
+				}
+
+				return stringerDiff(p, named, fn.Body), nil
+			}
+		}
+	}
+
+	return Diff{}, fmt.Errorf("no String method found for %s", typ)
+}
+
+// receiver returns the named type fn is a method of.
+func receiver(p *packages.Package, fn *ast.FuncDecl) *types.Named {
+	obj, ok := p.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	recv := obj.Type().(*types.Signature).Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, _ := recv.(*types.Named)
+
+	return named
+}
+
+// stringerDiff diffs the constants of named against the values named in body.
+func stringerDiff(p *packages.Package, named *types.Named, body *ast.BlockStmt) Diff {
+	s := stringerScan{p: p, named: named, covered: make(map[string]bool)}
+	s.statements(body.List)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SwitchStmt:
+			s.switchCases(n)
+		case *ast.CaseClause:
+			s.statements(n.Body)
+		case *ast.IndexExpr:
+			s.mapKeys(n.X)
+		}
+
+		return true
+	})
+
+	diff := Diff{Missing: Collection{Type: named.String()}}
+	if p.Module != nil {
+		diff.Missing.Module = Module{Path: p.Module.Path, Version: p.Module.Version, Dir: p.Module.Dir}
+	}
+
+	declared := make(map[string]bool)
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		k, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(k.Type(), named) {
+			continue
+		}
+
+		val := k.Val().ExactString()
+		declared[val] = true
+		if !s.covered[val] {
+			diff.Missing.Enums = append(diff.Missing.Enums, Enum{
+				Name:  k.Name(),
+				Value: val,
+				Type:  named.String(),
+				Pos:   p.Fset.Position(k.Pos()),
+			})
+		}
+	}
+
+	for val := range s.covered {
+		if !declared[val] {
+			diff.Extra = append(diff.Extra, val)
+		}
+	}
+	sort.SliceStable(diff.Missing.Enums, func(i, j int) bool { return diff.Missing.Enums[i].Name < diff.Missing.Enums[j].Name })
+	sort.Strings(diff.Extra)
+
+	return diff
+}
+
+// stringerScan collects the constant values a String method has names for.
+type stringerScan struct {
+	p       *packages.Package
+	named   *types.Named
+	covered map[string]bool // the exact strings of the constant values
+}
+
+// switchCases covers the values of the cases of a switch on the value, or of
+// a switch without a tag the values compared for equality like i == 10.
+func (s *stringerScan) switchCases(sw *ast.SwitchStmt) {
+	for _, stmt := range sw.Body.List {
+		clause := stmt.(*ast.CaseClause)
+		for _, expr := range clause.List {
+			if sw.Tag == nil {
+				bin, ok := expr.(*ast.BinaryExpr)
+				if !ok || bin.Op != token.EQL {
+					continue
+				}
+				s.cover(bin.X)
+				s.cover(bin.Y)
+				continue
+			}
+
+			s.cover(expr)
+		}
+	}
+}
+
+// cover marks the value of expr as covered if it's a constant.
+func (s *stringerScan) cover(expr ast.Expr) {
+	if tv, ok := s.p.TypesInfo.Types[expr]; ok && tv.Value != nil {
+		s.covered[tv.Value.ExactString()] = true
+	}
+}
+
+// statements covers the values named by the index arrays stringer generates
+// that are used in list, where the value is first shifted down by any
+// i -= n statement in the list itself. Nested case clauses are their own
+// list, as stringer shifts the value per case when there are several runs of
+// values.
+func (s *stringerScan) statements(list []ast.Stmt) {
+	var offset int64
+	for _, stmt := range list {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.SUB_ASSIGN || len(assign.Rhs) != 1 {
+			continue
+		}
+
+		if tv, ok := s.p.TypesInfo.Types[assign.Rhs[0]]; ok && tv.Value != nil {
+			if n, ok := constant.Int64Val(tv.Value); ok {
+				offset += n
+			}
+		}
+	}
+
+	seen := make(map[*types.Var]bool)
+	for _, stmt := range list {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if _, ok := n.(*ast.CaseClause); ok {
+				return false
+			}
+
+			ident, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			v, ok := s.p.TypesInfo.Uses[ident].(*types.Var)
+			if !ok || seen[v] || v.Parent() != v.Pkg().Scope() {
+				return true
+			}
+			arr, ok := v.Type().(*types.Array)
+			if !ok {
+				return true
+			}
+			if elem, ok := arr.Elem().Underlying().(*types.Basic); !ok || elem.Info()&types.IsInteger == 0 {
+				return true
+			}
+
+			// The array has the offset of every name and one past the last
+			seen[v] = true
+			for i := int64(0); i < arr.Len()-1; i++ {
+				s.covered[strconv.FormatInt(offset+i, 10)] = true
+			}
+
+			return true
+		})
+	}
+}
+
+// mapKeys covers the keys of x when it's a package level map literal keyed by the type.
+func (s *stringerScan) mapKeys(x ast.Expr) {
+	ident, ok := x.(*ast.Ident)
+	if !ok {
+		return
+	}
+	v, ok := s.p.TypesInfo.Uses[ident].(*types.Var)
+	if !ok {
+		return
+	}
+	if m, ok := v.Type().Underlying().(*types.Map); !ok || !types.Identical(m.Key(), s.named) {
+		return
+	}
+
+	for _, f := range s.p.Syntax {
+		for _, d := range f.Decls {
+			gen, ok := d.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				spec, ok := spec.(*ast.ValueSpec)
+				if !ok || len(spec.Values) != len(spec.Names) {
+					continue
+				}
+
+				for i, name := range spec.Names {
+					lit, ok := spec.Values[i].(*ast.CompositeLit)
+					if !ok || s.p.TypesInfo.Defs[name] != v {
+						continue
+					}
+
+					for _, elt := range lit.Elts {
+						if kv, ok := elt.(*ast.KeyValueExpr); ok {
+							s.cover(kv.Key)
+						}
+					}
+				}
+			}
+		}
+	}
+}
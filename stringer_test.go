@@ -0,0 +1,41 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestStringerDiff(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		typ     string
+		missing []string
+		extra   []string
+	}{
+		{name: "generated by stringer", typ: "stringer.Color"},
+		{name: "generated by stringer before a constant was added", typ: "stringer.Weekday", missing: []string{"Wednesday = 3"}},
+		{name: "switching on the value", typ: "stringer.Level", missing: []string{"Error = 3"}},
+		{name: "looking up a map", typ: "stringer.Size", missing: []string{`Large = "l"`}, extra: []string{`"m"`}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := enums.StringerDiff("./testdata/stringer", tc.typ)
+			require.NoError(t, err)
+
+			var missing []string
+			for _, e := range diff.Missing.Enums {
+				missing = append(missing, e.Name+" = "+e.Value)
+			}
+			require.Equal(t, tc.missing, missing)
+			require.Equal(t, tc.extra, diff.Extra)
+		})
+	}
+
+	t.Run("returns an error without a String method", func(t *testing.T) {
+		_, err := enums.StringerDiff("./testdata/stringer", "stringer.Plain")
+
+		require.EqualError(t, err, "no String method found for stringer.Plain")
+	})
+}
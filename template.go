@@ -0,0 +1,34 @@
+package enums
+
+import (
+	"io"
+	"text/template"
+)
+
+// Execute writes the collection to w with tmpl, for exporting enums to
+// formats this package doesn't know about, such as Terraform variables, Helm
+// values, or constants in another language.
+//
+// The template is executed with the Collection as its data:
+//
+//	.Type         the import path of the type, e.g. github.com/org/app/feature.Flag
+//	.FieldName    the identifier field for struct enums, empty otherwise
+//	.Module       the module the type is declared in, with .Path, .Version, and .Dir
+//	.Interface    whether the values are the concrete types assigned to an interface
+//	.Enums        the enums sorted by name, each with:
+//	  .Name       the name of the declaration, e.g. FlagDarkMode
+//	  .Value      the value as written in the source, string literals keep their quotes
+//	  .Type       the import path of the type the enum is declared as
+//	  .Pos        where the enum is declared, with .Filename and .Line
+//	  .Doc        the doc comment of the declaration
+//	  .Fields     the source of every field set in a struct literal, by field name
+//	  .Labels     the labels set by directives, such as "group"
+//	.Diagnostics  the declarations that were skipped, with .Name, .Pos, and .Reason
+//
+// Example:
+//
+//	tmpl := template.Must(template.New("tf").Parse(`{{range .Enums}}{{.Name}} = {{.Value}}{{"\n"}}{{end}}`))
+//	flags.Execute(tmpl, os.Stdout)
+func (c Collection) Execute(tmpl *template.Template, w io.Writer) error {
+	return tmpl.Execute(w, c)
+}
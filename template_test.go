@@ -0,0 +1,41 @@
+package enums_test
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestCollection_Execute(t *testing.T) {
+	t.Run("executes the template with the collection", func(t *testing.T) {
+		collection, err := enums.All("./testdata/multimatch", "multimatch.Flag")
+		require.NoError(t, err)
+		tmpl := template.Must(template.New("ruby").Parse(
+			"module Flags\n{{range .Enums}}  # {{.Doc}}\n  {{.Name}} = {{.Value}}\n{{end}}end\n",
+		))
+		var buf bytes.Buffer
+
+		require.NoError(t, collection.Execute(tmpl, &buf))
+
+		require.Equal(
+			t,
+			"module Flags\n"+
+				"  # FlagSomethingCouldBe is documented\nover two lines.\n"+
+				"  FlagSomethingCouldBe = \"flag-whatever\"\n"+
+				"  # with a line comment\n"+
+				"  FlagSomethingElse = \"flag-whomever\"\n"+
+				"end\n",
+			buf.String(),
+		)
+	})
+
+	t.Run("returns errors from the template", func(t *testing.T) {
+		tmpl := template.Must(template.New("broken").Parse("{{.Nope}}"))
+
+		require.Error(t, enums.Collection{}.Execute(tmpl, &bytes.Buffer{}))
+	})
+}
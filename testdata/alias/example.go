@@ -0,0 +1,11 @@
+package alias
+
+type Flag string
+
+const (
+	FlagCheckout Flag = "checkout-v2"
+	FlagSearch   Flag = "search"
+
+	//enums:alias-of=FlagCheckout
+	FlagCheckoutLegacy Flag = "checkout"
+)
@@ -0,0 +1,9 @@
+package badimport
+
+import "github.com/gaqzi/enums/testdata/doesnotexist"
+
+type Flag string
+
+const FlagOn Flag = "flag-on"
+
+var FlagOther = doesnotexist.Flag
@@ -0,0 +1,5 @@
+package cgo
+
+type Codec string
+
+const CodecGo Codec = "go"
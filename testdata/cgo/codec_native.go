@@ -0,0 +1,8 @@
+package cgo
+
+// #include <stdlib.h>
+import "C"
+
+const CodecNative Codec = "native"
+
+func free(p *C.char) { C.free(nil) }
@@ -0,0 +1,8 @@
+package billing
+
+type EventType string
+
+const (
+	EventCharged  EventType = "payment.charged"
+	EventRefunded EventType = "payment.refunded"
+)
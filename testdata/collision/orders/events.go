@@ -0,0 +1,9 @@
+package orders
+
+type EventType string
+
+const (
+	EventCreated  EventType = "order.created"
+	EventShipped  EventType = "order.shipped"
+	EventRefunded EventType = "payment.refunded"
+)
@@ -0,0 +1,32 @@
+package coverage
+
+import "errors"
+
+type Flag string
+
+const (
+	FlagOn     Flag = "on"
+	FlagOff    Flag = "off"
+	FlagBroken Flag = "broken"
+)
+
+func AllFlags() []Flag {
+	return []Flag{FlagOn, FlagOff, FlagBroken}
+}
+
+var labels = map[Flag]string{
+	FlagOn:  "On",
+	FlagOff: "Off",
+}
+
+func handle(f Flag) error {
+	switch f {
+	case FlagOn:
+		return nil
+	default:
+		return errors.New("unhandled")
+	}
+}
+
+// Other types aren't sites of the flag
+var names = []string{"on", "off"}
@@ -0,0 +1,4 @@
+package a
+
+// Flag is declared here but its values are declared in package b.
+type Flag string
@@ -0,0 +1,26 @@
+package b
+
+import "github.com/gaqzi/enums/testdata/crosspkg/a"
+
+const (
+	FlagX      a.Flag = "x"
+	FlagY      a.Flag = "y"
+	FlagZ      a.Flag = "z"
+	FlagUnused a.Flag = "unused"
+)
+
+func handle(f a.Flag) string {
+	switch f {
+	case FlagX:
+		return "x"
+	case FlagY:
+		return "y"
+	}
+
+	return ""
+}
+
+var next = map[a.Flag]a.Flag{
+	FlagX: FlagY,
+	FlagY: FlagZ,
+}
@@ -0,0 +1,29 @@
+package diagnostics
+
+type Flag string
+
+const FlagValid Flag = "flag-valid"
+
+var (
+	FlagComputed = Flag("flag-" + "computed")
+	AllFlags     = []Flag{FlagValid}
+)
+
+type Untagged struct {
+	Name string
+}
+
+var UntaggedValue = Untagged{Name: "untagged"}
+
+type Stage int
+
+const (
+	StageOne Stage = iota
+	StageTwo
+)
+
+type Pair struct {
+	Left, Right string `enums:"identifier"`
+}
+
+var PairValue = Pair{Left: "left", Right: "right"}
@@ -0,0 +1,17 @@
+package embeddedtag
+
+type Name string
+
+type Flag struct {
+	Name      `enums:"identifier"`
+	DefaultOn bool
+}
+
+var (
+	FlagOn  = Flag{Name: "on", DefaultOn: true}
+	FlagOff = Flag{"off", false}
+)
+
+func AllFlags() []Flag {
+	return []Flag{FlagOn, FlagOff}
+}
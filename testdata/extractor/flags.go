@@ -0,0 +1,10 @@
+package extractor
+
+type Flag string
+
+func lookup(name string) Flag { return Flag(name) }
+
+var (
+	FlagA = lookup("flag-a")
+	FlagB = lookup(string(FlagA) + "-b")
+)
@@ -0,0 +1,23 @@
+package full
+
+type Base struct {
+	Name string `enums:"identifier"`
+}
+
+type EmbeddedFlag struct {
+	Base
+	DefaultOn bool
+}
+
+var (
+	FlagEmbedded        = EmbeddedFlag{Base: Base{Name: "flag-embedded"}}
+	FlagEmbeddedDefault = EmbeddedFlag{DefaultOn: true, Base: Base{Name: "flag-embedded-default"}}
+)
+
+func AllEmbeddedFlags() []EmbeddedFlag {
+	return []EmbeddedFlag{FlagEmbedded, FlagEmbeddedDefault}
+}
+
+func MissingEmbeddedFlags() []EmbeddedFlag {
+	return []EmbeddedFlag{FlagEmbedded}
+}
@@ -0,0 +1,10 @@
+package generated
+
+//go:generate flaggen -type=Flag
+
+type Flag string
+
+const (
+	FlagOn  Flag = "on"
+	FlagOff Flag = "off"
+)
@@ -0,0 +1,11 @@
+package stale
+
+//go:generate stringer -type=Level
+
+type Level int
+
+const (
+	LevelDebug Level = 1
+	LevelInfo  Level = 2
+	LevelWarn  Level = 3
+)
@@ -0,0 +1,15 @@
+package group
+
+type Flag string
+
+//enums:group=payments
+const (
+	FlagRefunds Flag = "refunds"
+
+	//enums:group=checkout
+	FlagOneClick Flag = "one-click"
+
+	FlagInvoices Flag = "invoices"
+)
+
+var FlagDarkMode Flag = "dark-mode"
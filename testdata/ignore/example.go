@@ -0,0 +1,23 @@
+package ignore
+
+type Flag string
+
+const (
+	FlagOn  Flag = "flag-on"
+	FlagOff Flag = "flag-off"
+
+	//enums:ignore only used to check the zero value in tests
+	flagTestOnly Flag = "flag-test-only"
+
+	flagInternal Flag = "flag-internal" //enums:ignore
+)
+
+//enums:ignore
+var FlagDeprecated Flag = "flag-deprecated"
+
+//enums:ignored isn't the ignore directive
+var FlagIgnored Flag = "flag-ignored"
+
+func AllFlags() []Flag {
+	return []Flag{FlagOn, FlagOff, FlagIgnored}
+}
@@ -0,0 +1,10 @@
+package keys
+
+type Key rune
+
+const (
+	Tab     Key = '\t'
+	Enter   Key = '\n'
+	Space   Key = ' '
+	LetterA Key = 'a'
+)
@@ -3,6 +3,8 @@ package multimatch
 type Flag string
 
 var (
+	// FlagSomethingCouldBe is documented
+	// over two lines.
 	FlagSomethingCouldBe Flag = "flag-whatever"
-	FlagSomethingElse    Flag = "flag-whomever"
+	FlagSomethingElse    Flag = "flag-whomever" // with a line comment
 )
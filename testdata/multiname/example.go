@@ -0,0 +1,13 @@
+package multiname
+
+type Flag string
+
+var FlagA, FlagB Flag = "flag-a", "flag-b"
+
+const FlagC, FlagD Flag = "flag-c", "flag-d"
+
+var FlagE, FlagF = pair()
+
+func pair() (Flag, Flag) {
+	return "flag-e", "flag-f"
+}
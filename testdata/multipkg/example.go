@@ -0,0 +1,5 @@
+package multipkg
+
+type Flag string
+
+const FlagOn Flag = "on"
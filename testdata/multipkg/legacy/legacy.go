@@ -0,0 +1,6 @@
+package legacy
+
+import "github.com/gaqzi/enums/testdata/multipkg"
+
+// FlagOn has the same name as multipkg.FlagOn
+const FlagOn multipkg.Flag = "legacy-on"
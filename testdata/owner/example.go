@@ -0,0 +1,12 @@
+package owner
+
+type Flag string
+
+//enums:owner=team-payments
+const (
+	FlagInvoices Flag = "invoices"
+	FlagRefunds  Flag = "refunds"
+	FlagSearch   Flag = "search" //enums:owner=team-discovery
+)
+
+const FlagBeta Flag = "beta"
@@ -0,0 +1,10 @@
+package broken
+
+import (
+	"github.com/gaqzi/enums/testdata/doesnotexist"
+	"github.com/gaqzi/enums/testdata/partial"
+)
+
+const FlagOff partial.Flag = "off"
+
+var FlagOther = doesnotexist.Flag
@@ -0,0 +1,5 @@
+package partial
+
+type Flag string
+
+const FlagOn Flag = "on"
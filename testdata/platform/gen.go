@@ -0,0 +1,6 @@
+//go:build ignore
+
+package main
+
+// Generators aren't part of the package under any constraints
+const ShellGen = "gen"
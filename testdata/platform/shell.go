@@ -0,0 +1,5 @@
+package platform
+
+type Shell string
+
+const ShellSh Shell = "sh"
@@ -0,0 +1,6 @@
+//go:build plan9 || (linux && rc)
+
+package platform
+
+// ShellRc is the shell of Plan 9.
+const ShellRc Shell = "rc"
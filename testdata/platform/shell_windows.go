@@ -0,0 +1,3 @@
+package platform
+
+const ShellPowerShell Shell = "powershell"
@@ -0,0 +1,8 @@
+package feature
+
+type Flag string
+
+const (
+	FlagOn  Flag = "on"
+	FlagOld Flag = "old"
+)
@@ -0,0 +1,33 @@
+package registration
+
+import "os"
+
+type Flag struct {
+	name      string
+	defaultOn bool
+}
+
+type FlagOption func(*Flag)
+
+func WithDefault(on bool) FlagOption {
+	return func(f *Flag) { f.defaultOn = on }
+}
+
+func Register(name string, opts ...FlagOption) *Flag {
+	f := &Flag{name: name}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+const prefix = "flag-"
+
+var (
+	// FlagX is on by default.
+	FlagX       = Register("flag-x", WithDefault(true))
+	FlagY       = Register("flag-y")
+	FlagZ       = Register(prefix + "z")
+	FlagRuntime = Register(os.Getenv("FLAG"))
+)
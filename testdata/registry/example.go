@@ -0,0 +1,26 @@
+package registry
+
+type Encoder interface {
+	Encode(v interface{}) ([]byte, error)
+}
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) Encode(v interface{}) ([]byte, error) { return nil, nil }
+
+type xmlEncoder struct{}
+
+func (*xmlEncoder) Encode(v interface{}) ([]byte, error) { return nil, nil }
+
+var (
+	JSON Encoder = jsonEncoder{}
+	XML  Encoder = &xmlEncoder{}
+)
+
+func AllEncoders() []Encoder {
+	return []Encoder{JSON, XML}
+}
+
+func MissingEncoders() []Encoder {
+	return []Encoder{JSON}
+}
@@ -0,0 +1,5 @@
+package billing
+
+import "github.com/gaqzi/enums/testdata/sharedtype/events"
+
+const EventRefunded events.Type = "payment.refunded"
@@ -0,0 +1,4 @@
+package events
+
+// Type is shared by the events of every package.
+type Type string
@@ -0,0 +1,8 @@
+package orders
+
+import "github.com/gaqzi/enums/testdata/sharedtype/events"
+
+const (
+	EventCreated  events.Type = "order.created"
+	EventRefunded events.Type = "payment.refunded"
+)
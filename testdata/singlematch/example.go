@@ -5,3 +5,9 @@ type Flag string
 var (
 	FlagSomethingCouldBe Flag = "flag-whatever"
 )
+
+// Flags declared inside of functions aren't part of the package's enums
+func isWhatever(f Flag) bool {
+	var whatever Flag = "flag-whatever"
+	return f == whatever
+}
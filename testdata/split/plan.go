@@ -0,0 +1,6 @@
+package split
+
+type Plan struct {
+	ID    string `enums:"identifier"`
+	Price int
+}
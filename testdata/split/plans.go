@@ -0,0 +1,6 @@
+package split
+
+var (
+	PlanFree = Plan{ID: "free", Price: 0}
+	PlanPro  = Plan{ID: "pro", Price: 10}
+)
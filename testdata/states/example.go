@@ -0,0 +1,30 @@
+package states
+
+type State string
+
+const (
+	StateNew       State = "new"
+	StatePaid      State = "paid"
+	StateShipped   State = "shipped"
+	StateCancelled State = "cancelled"
+	StateLost      State = "lost"
+)
+
+var transitions = map[State][]State{
+	StateNew:  {StatePaid, StateCancelled},
+	StatePaid: {StateShipped, StateCancelled},
+}
+
+func reopen(s State) State {
+	switch s {
+	case StateCancelled:
+		return StateNew
+	}
+
+	return s
+}
+
+// Maps of states to other values aren't transitions
+var labels = map[State]string{
+	StateLost: "Lost",
+}
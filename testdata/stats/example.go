@@ -0,0 +1,25 @@
+package stats
+
+type Flag string
+
+const (
+	FlagOn  Flag = "on"
+	FlagOff Flag = "off"
+	// FlagLegacy was the first flag.
+	//
+	// Deprecated: use FlagOn.
+	FlagLegacy Flag = "legacy"
+)
+
+type Stage int
+
+const (
+	StageAlpha Stage = 1
+	StageBeta  Stage = 2
+)
+
+func enabled(f Flag) bool {
+	return f == FlagOn || f == FlagOff
+}
+
+var current = StageAlpha
@@ -0,0 +1,87 @@
+package stringer
+
+import "strconv"
+
+// Color has a String method as generated by stringer
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+const _Color_name = "RedGreenBlue"
+
+var _Color_index = [...]uint8{0, 3, 8, 12}
+
+func (i Color) String() string {
+	if i < 0 || i >= Color(len(_Color_index)-1) {
+		return "Color(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Color_name[_Color_index[i]:_Color_index[i+1]]
+}
+
+// Weekday was generated by stringer before Wednesday was added
+type Weekday int
+
+const (
+	Monday Weekday = iota + 1
+	Tuesday
+	Wednesday
+)
+
+const _Weekday_name = "MondayTuesday"
+
+var _Weekday_index = [...]uint8{0, 6, 13}
+
+func (i Weekday) String() string {
+	i -= 1
+	if i < 0 || i >= Weekday(len(_Weekday_index)-1) {
+		return "Weekday(" + strconv.FormatInt(int64(i+1), 10) + ")"
+	}
+	return _Weekday_name[_Weekday_index[i]:_Weekday_index[i+1]]
+}
+
+// Level has a hand written String method that forgot Error
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+func (l Level) String() string {
+	switch l {
+	case Debug:
+		return "debug"
+	case Info, Warn:
+		return "info"
+	}
+
+	return "unknown"
+}
+
+// Size is named through a map that has a name too many
+type Size string
+
+const (
+	Small Size = "s"
+	Large Size = "l"
+)
+
+var sizeNames = map[Size]string{
+	Small: "small",
+	"m":   "medium",
+}
+
+func (s *Size) String() string {
+	return sizeNames[*s]
+}
+
+// Plain has no String method
+type Plain int
+
+const PlainOne Plain = 1
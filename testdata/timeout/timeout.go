@@ -0,0 +1,11 @@
+package timeout
+
+import "time"
+
+type Timeout time.Duration
+
+const (
+	Short  Timeout = 5 * time.Second
+	Medium Timeout = Timeout(time.Minute)
+	Long   Timeout = 2*Timeout(time.Minute) + 30*Timeout(time.Second)
+)
@@ -0,0 +1,10 @@
+package typeerror
+
+// Mid-refactor code that doesn't compile shouldn't hide the enums
+func broken() Flag {
+	return undefinedFunction()
+}
+
+var FlagConverted = Flag(undefinedVariable)
+
+var FlagFromCall = newFlag("flag-from-call")
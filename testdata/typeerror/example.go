@@ -0,0 +1,8 @@
+package typeerror
+
+type Flag string
+
+const (
+	FlagOn  Flag = "flag-on"
+	FlagOff Flag = "flag-off"
+)
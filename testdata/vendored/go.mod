@@ -0,0 +1,5 @@
+module example.com/vendored
+
+go 1.21
+
+require example.com/flags v1.0.0
@@ -0,0 +1,10 @@
+package app
+
+import "example.com/flags"
+
+type Flag string
+
+const (
+	FlagOn  Flag = flags.Prefix + "on"
+	FlagOff Flag = flags.Prefix + "off"
+)
@@ -0,0 +1,5 @@
+module example.com/app
+
+go 1.25.0
+
+require example.com/flags v1.0.0
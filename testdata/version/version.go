@@ -0,0 +1,9 @@
+package version
+
+type Version float64
+
+const (
+	V1   Version = 1.0
+	V1_5 Version = 1.5
+	V2   Version = 2.
+)
@@ -0,0 +1,8 @@
+package billing
+
+import "example.com/orders"
+
+const (
+	StatusPaid     orders.Status = "paid"
+	StatusRefunded orders.Status = "refunded"
+)
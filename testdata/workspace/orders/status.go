@@ -0,0 +1,8 @@
+package orders
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusShipped Status = "shipped"
+)
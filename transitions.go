@@ -0,0 +1,204 @@
+package enums
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Transitions are the moves between the enums of a type used as the states
+// of a state machine, as found in transition maps and switches.
+type Transitions struct {
+	Collection Collection
+	Edges      map[string][]string // the names of the states each state can move to, keyed by name
+	Sites      []Site              // the maps and switches the transitions are declared in, ordered by position
+}
+
+// NoOutgoing returns the names of the states that can't be moved out of,
+// in the order of the collection. Expected for the final states.
+func (t Transitions) NoOutgoing() []string {
+	var names []string
+	for _, e := range t.Collection.Enums {
+		if len(t.Edges[e.Name]) == 0 {
+			names = append(names, e.Name)
+		}
+	}
+
+	return names
+}
+
+// NoIncoming returns the names of the states that can't be moved into, in
+// the order of the collection. Expected for the initial state.
+func (t Transitions) NoIncoming() []string {
+	incoming := make(map[string]bool)
+	for _, to := range t.Edges {
+		for _, name := range to {
+			incoming[name] = true
+		}
+	}
+
+	var names []string
+	for _, e := range t.Collection.Enums {
+		if !incoming[e.Name] {
+			names = append(names, e.Name)
+		}
+	}
+
+	return names
+}
+
+// String outputs the states without outgoing or incoming transitions.
+func (t Transitions) String() string {
+	var b strings.Builder
+	if names := t.NoOutgoing(); len(names) > 0 {
+		fmt.Fprintf(&b, "States with no outgoing transitions:\n\t%s\n", strings.Join(names, "\n\t"))
+	}
+	if names := t.NoIncoming(); len(names) > 0 {
+		fmt.Fprintf(&b, "States with no incoming transitions:\n\t%s\n", strings.Join(names, "\n\t"))
+	}
+
+	return b.String()
+}
+
+// StateTransitions finds the transitions between the enums of typ in the
+// packages matching pkg, to report states that are unreachable or can't be
+// left, like exhaustiveness but for the graph of a state machine.
+//
+// A transition is every state mentioned in the value of a map literal keyed
+// by typ whose values are typ, a slice of typ, or a map keyed by typ, such as
+// map[State][]State, and every state mentioned in the body of a case of a
+// switch on typ.
+//
+// Example:
+//
+//	transitions, _ := StateTransitions("./...", "order.State")
+//	fmt.Print(transitions)
+func StateTransitions(pkg, typ string, opts ...Option) (Transitions, error) {
+	c := newConfig(opts)
+	ctx, cancel := c.context()
+	defer cancel()
+
+	pkgs, err := load(ctx, c, pkg)
+	if err != nil {
+		return Transitions{}, err
+	}
+
+	collection, err := collect(ctx, c, pkgs, typ)
+	if err != nil {
+		return Transitions{}, err
+	}
+
+	t := Transitions{Collection: collection, Edges: make(map[string][]string)}
+	declared := make(map[string]bool, len(collection.Enums))
+	for _, e := range collection.Enums {
+		declared[e.qualifiedName()] = true
+	}
+
+	for _, p := range pkgs {
+		for _, f := range p.Syntax {
+			for _, d := range f.Decls {
+				switch d := d.(type) {
+				case *ast.FuncDecl:
+					if d.Body != nil {
+						t.add(p, typ, declared, funcName(d), d.Body)
+					}
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						if spec, ok := spec.(*ast.ValueSpec); ok {
+							t.add(p, typ, declared, spec.Names[0].Name, spec)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(t.Sites, func(i, j int) bool {
+		a, b := t.Sites[i].Pos, t.Sites[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		return a.Offset < b.Offset
+	})
+
+	return t, nil
+}
+
+// add adds the transitions of the maps and switches of typ in node.
+func (t *Transitions) add(p *packages.Package, typ string, declared map[string]bool, name string, node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		var site Site
+		var moves [][2]ast.Node // from and to
+		switch n := n.(type) {
+		case *ast.SwitchStmt:
+			if n.Tag == nil || p.TypesInfo.TypeOf(n.Tag) == nil || !matchesType(p.TypesInfo.TypeOf(n.Tag), typ) {
+				return true
+			}
+
+			site.Kind = "switch"
+			for _, stmt := range n.Body.List {
+				clause := stmt.(*ast.CaseClause)
+				for _, from := range clause.List {
+					moves = append(moves, [2]ast.Node{from, &ast.BlockStmt{List: clause.Body}})
+				}
+			}
+		case *ast.CompositeLit:
+			lit := p.TypesInfo.TypeOf(n)
+			if lit == nil {
+				return true
+			}
+			m, ok := lit.Underlying().(*types.Map)
+			if !ok || !matchesType(m.Key(), typ) || !isStates(m.Elem(), typ) {
+				return true
+			}
+
+			site.Kind = "map"
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					moves = append(moves, [2]ast.Node{kv.Key, kv.Value})
+				}
+			}
+		default:
+			return true
+		}
+
+		site.Name, site.Package, site.Pos = name, p.PkgPath, p.Fset.Position(n.Pos())
+		for _, move := range moves {
+			to := mentioned(p, declared, move[1])
+			for _, from := range mentioned(p, declared, move[0]) {
+				site.Enums = append(site.Enums, from)
+				for _, name := range to {
+					if !slices.Contains(t.Edges[from], name) {
+						t.Edges[from] = append(t.Edges[from], name)
+					}
+				}
+			}
+		}
+		if len(site.Enums) > 0 {
+			t.Sites = append(t.Sites, site)
+		}
+
+		return true
+	})
+}
+
+// isStates returns whether the values of a transition map of typ hold
+// states, either as typ itself, a slice or array of typ, or a map keyed by typ.
+func isStates(elem types.Type, typ string) bool {
+	switch t := elem.Underlying().(type) {
+	case *types.Slice:
+		return matchesType(t.Elem(), typ)
+	case *types.Array:
+		return matchesType(t.Elem(), typ)
+	case *types.Map:
+		return matchesType(t.Key(), typ)
+	default:
+		return matchesType(elem, typ)
+	}
+}
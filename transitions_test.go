@@ -0,0 +1,50 @@
+package enums_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestStateTransitions(t *testing.T) {
+	transitions, err := enums.StateTransitions("./testdata/states", "states.State")
+	require.NoError(t, err)
+
+	t.Run("finds the transitions in maps and switches", func(t *testing.T) {
+		require.Equal(
+			t,
+			map[string][]string{
+				"StateNew":       {"StatePaid", "StateCancelled"},
+				"StatePaid":      {"StateShipped", "StateCancelled"},
+				"StateCancelled": {"StateNew"},
+			},
+			transitions.Edges,
+		)
+
+		var sites []string
+		for _, s := range transitions.Sites {
+			sites = append(sites, s.String())
+		}
+		require.Equal(t, []string{"transitions map", "reopen switch"}, sites)
+	})
+
+	t.Run("returns the states that can't be left or reached", func(t *testing.T) {
+		require.Equal(t, []string{"StateLost", "StateShipped"}, transitions.NoOutgoing())
+		require.Equal(t, []string{"StateLost"}, transitions.NoIncoming())
+		require.Equal(
+			t,
+			"States with no outgoing transitions:\n\tStateLost\n\tStateShipped\n"+
+				"States with no incoming transitions:\n\tStateLost\n",
+			transitions.String(),
+		)
+	})
+}
+
+func TestStateTransitions_DeclaredInAnotherPackage(t *testing.T) {
+	transitions, err := enums.StateTransitions("./testdata/crosspkg/...", "a.Flag")
+	require.NoError(t, err)
+
+	require.Equal(t, map[string][]string{"FlagX": {"FlagY"}, "FlagY": {"FlagZ"}}, transitions.Edges)
+}
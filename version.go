@@ -0,0 +1,130 @@
+package enums
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// AllAtVersion finds variables of typ in pkg as published in version of module.
+//
+// The module is downloaded with the go command, so GOPROXY and friends are
+// honored, and pkg is resolved relative to the root of the module.
+//
+// Example:
+//
+//	AllAtVersion("github.com/acme/app", "v1.4.0", "./feature", "feature.Flag")
+func AllAtVersion(module, version, pkg, typ string, opts ...Option) (Collection, error) {
+	c := newConfig(opts)
+
+	src, err := downloadModule(c.env, module, version)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	// The module cache is read-only and the module may need its go.sum
+	// updated to resolve dependencies, so scan a writable copy.
+	dir, err := os.MkdirTemp("", "enums-version-")
+	if err != nil {
+		return Collection{}, fmt.Errorf("failed to create module directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := copyDir(src, dir); err != nil {
+		return Collection{}, fmt.Errorf("failed to copy module %s@%s: %w", module, version, err)
+	}
+
+	c.dir = dir
+	c.env = modModEnv(c.env)
+
+	return all(c, pkg, typ)
+}
+
+// modModEnv returns env, or the environment of the process when env is nil,
+// with -mod=mod added to GOFLAGS in place of any other -mod flag.
+func modModEnv(env []string) []string {
+	if env == nil {
+		env = os.Environ()
+	}
+
+	var flags []string
+	for _, flag := range strings.Fields(goflags(env)) {
+		if !isModFlag(flag) {
+			flags = append(flags, flag)
+		}
+	}
+
+	return append(append([]string(nil), env...), "GOFLAGS="+strings.Join(append(flags, "-mod=mod"), " "))
+}
+
+// downloadModule makes sure module@version is in the module cache and returns its directory.
+func downloadModule(env []string, module, version string) (string, error) {
+	// go mod download reports errors in the JSON output as well as with the
+	// exit code, the JSON has the more helpful message so always parse it.
+	cmd := exec.Command("go", "mod", "download", "-json", module+"@"+version)
+	cmd.Env = env
+	out, cmdErr := cmd.Output()
+
+	var info struct {
+		Dir   string
+		Error string
+	}
+	if err := json.Unmarshal(out, &info); err != nil {
+		if cmdErr != nil {
+			return "", fmt.Errorf("failed to download module %s@%s: %w", module, version, cmdErr)
+		}
+
+		return "", fmt.Errorf("failed to read go mod download output: %w", err)
+	}
+
+	if info.Error != "" {
+		return "", fmt.Errorf("failed to download module %s@%s: %s", module, version, info.Error)
+	}
+
+	return info.Dir, nil
+}
+
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
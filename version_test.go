@@ -0,0 +1,33 @@
+package enums_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+	"github.com/gaqzi/enums/internal/goproxy"
+)
+
+func TestAllAtVersion(t *testing.T) {
+	proxy := goproxy.Publish(t, "./testdata/published", "example.com/published", "v1.0.0")
+	env := append(os.Environ(),
+		"GOPROXY=file://"+proxy,
+		"GOSUMDB=off",
+		"GOMODCACHE="+t.TempDir(),
+		"GOFLAGS=-modcacherw", // so the test can remove the module cache
+	)
+
+	t.Run("finds the enums of the published version", func(t *testing.T) {
+		matches, err := enums.AllAtVersion("example.com/published", "v1.0.0", "./feature", "feature.Flag", enums.WithEnv(env))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"old", "on"}, matches.Values())
+	})
+
+	t.Run("fails for a version that isn't published", func(t *testing.T) {
+		_, err := enums.AllAtVersion("example.com/published", "v2.0.0", "./feature", "feature.Flag", enums.WithEnv(env))
+		require.ErrorContains(t, err, "failed to download module example.com/published@v2.0.0")
+	})
+}
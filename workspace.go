@@ -0,0 +1,65 @@
+package enums
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// workspaceEnv returns the environment to load the packages in dir with. The
+// go command refuses to load the packages of a go.work workspace with
+// -mod=mod, a common GOFLAGS setting, so the flag is dropped from GOFLAGS
+// when dir is in a workspace. -mod=readonly and -mod=vendor work with
+// workspaces and are kept. env is returned as is otherwise.
+func workspaceEnv(ctx context.Context, dir string, env []string) []string {
+	current := env
+	if current == nil {
+		current = os.Environ()
+	}
+
+	flags := goflags(current)
+	if !slices.ContainsFunc(strings.Fields(flags), isModModFlag) {
+		return env
+	}
+
+	cmd := exec.CommandContext(ctx, "go", "env", "GOWORK")
+	cmd.Dir = dir
+	cmd.Env = current
+	out, err := cmd.Output()
+	if gowork := strings.TrimSpace(string(out)); err != nil || gowork == "" || gowork == "off" {
+		return env
+	}
+
+	var kept []string
+	for _, flag := range strings.Fields(flags) {
+		if !isModModFlag(flag) {
+			kept = append(kept, flag)
+		}
+	}
+
+	return append(append([]string(nil), current...), "GOFLAGS="+strings.Join(kept, " "))
+}
+
+// goflags returns the value of GOFLAGS in env.
+func goflags(env []string) string {
+	var goflags string
+	for _, kv := range env {
+		if v, ok := strings.CutPrefix(kv, "GOFLAGS="); ok {
+			goflags = v // the last one wins, like for exec.Cmd
+		}
+	}
+
+	return goflags
+}
+
+// isModFlag reports whether flag is a -mod flag.
+func isModFlag(flag string) bool {
+	return strings.HasPrefix(flag, "-mod=") || strings.HasPrefix(flag, "--mod=")
+}
+
+// isModModFlag reports whether flag is -mod=mod, which conflicts with workspace mode.
+func isModModFlag(flag string) bool {
+	return flag == "-mod=mod" || flag == "--mod=mod"
+}
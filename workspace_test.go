@@ -0,0 +1,75 @@
+package enums_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gaqzi/enums"
+)
+
+func TestAll_Workspace(t *testing.T) {
+	t.Run("finds the enums in every module of the workspace", func(t *testing.T) {
+		matches, err := enums.All("example.com/...", "orders.Status", enums.WithDir("./testdata/workspace"))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"paid", "pending", "refunded", "shipped"}, matches.Values())
+	})
+
+	t.Run("has the module of the type when enums are declared in other modules", func(t *testing.T) {
+		matches, err := enums.All("example.com/...", "orders.Status", enums.WithDir("./testdata/workspace"))
+		require.NoError(t, err)
+
+		require.Equal(t, "example.com/orders", matches.Module.Path)
+	})
+
+	t.Run("loads workspaces with -mod=mod in GOFLAGS", func(t *testing.T) {
+		t.Setenv("GOFLAGS", "-mod=mod")
+
+		matches, err := enums.All("example.com/orders", "orders.Status", enums.WithDir("./testdata/workspace"))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"pending", "shipped"}, matches.Values())
+	})
+
+	t.Run("loads vendored workspaces with -mod=vendor in GOFLAGS", func(t *testing.T) {
+		t.Setenv("GOFLAGS", "-mod=vendor")
+		t.Setenv("GOPROXY", "off")
+
+		matches, err := enums.All("./app", "app.Flag", enums.WithDir("./testdata/vendoredwork"))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"flag-off", "flag-on"}, matches.Values())
+	})
+
+	t.Run("a relative pattern is relative to the directory", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+
+		matches, err := enums.All("./orders", "orders.Status", enums.WithDir(wd+"/testdata/workspace"))
+		require.NoError(t, err)
+
+		require.Len(t, matches.Enums, 2)
+	})
+}
+
+func TestAll_WithVendor(t *testing.T) {
+	t.Run("loads the dependencies from the vendor directory", func(t *testing.T) {
+		t.Setenv("GOFLAGS", "-mod=mod")
+		t.Setenv("GOPROXY", "off")
+
+		matches, err := enums.All("./...", "vendored.Flag", enums.WithDir("./testdata/vendored"), enums.WithVendor())
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"flag-off", "flag-on"}, matches.Values())
+	})
+
+	t.Run("fails to resolve vendored dependencies with -mod=mod", func(t *testing.T) {
+		t.Setenv("GOFLAGS", "-mod=mod")
+		t.Setenv("GOPROXY", "off")
+
+		_, err := enums.All("./...", "vendored.Flag", enums.WithDir("./testdata/vendored"))
+		require.Error(t, err)
+	})
+}